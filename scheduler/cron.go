@@ -0,0 +1,256 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCron cron表达式不合法
+var ErrInvalidCron = errors.New("scheduler: invalid cron expression")
+
+// cronField cron表达式中一个字段的取值范围
+type cronField struct {
+	min, max int
+	names    map[string]int
+}
+
+var (
+	secondField = cronField{min: 0, max: 59}
+	minuteField = cronField{min: 0, max: 59}
+	hourField   = cronField{min: 0, max: 23}
+	domField    = cronField{min: 1, max: 31}
+	monthField  = cronField{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// 星期的7与0均表示周日
+	dowField = cronField{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronDescriptors 预定义的cron表达式
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronSchedule 解析后的cron表达式，每个字段以位图表示
+type cronSchedule struct {
+	expr                           string
+	second, minute, hour, dom, dow uint64
+	month                          uint64
+	domStar, dowStar               bool
+	timezone                       *time.Location
+}
+
+// ParseCron 解析cron表达式，时间按timezone计算，timezone为nil时为UTC。
+// 支持5个字段(分 时 日 月 周)或6个字段(秒 分 时 日 月 周)，字段支持*、?、列表(1,3)、范围(1-5)、步长(*/15、0-30/5)及月份和星期的英文缩写(JAN、MON)；
+// 也支持@yearly、@monthly、@weekly、@daily、@hourly及@every 1h30m。
+// 日与周均被限定时满足任意一个即触发，与标准cron一致
+func ParseCron(expr string, timezone *time.Location) (Schedule, error) {
+	if timezone == nil {
+		timezone = time.UTC
+	}
+	spec := strings.TrimSpace(expr)
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(spec, "@every ")))
+		if err != nil || d <= 0 {
+			return nil, fmt.Errorf("%w: %q: bad @every duration", ErrInvalidCron, expr)
+		}
+		return Every(d), nil
+	}
+	if descriptor, ok := cronDescriptors[strings.ToLower(spec)]; ok {
+		spec = descriptor
+	}
+
+	fields := strings.Fields(spec)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, fmt.Errorf("%w: %q: expected 5 or 6 fields, got %d", ErrInvalidCron, expr, len(fields))
+	}
+
+	s := &cronSchedule{expr: expr, timezone: timezone}
+	targets := []struct {
+		bits  *uint64
+		field cronField
+	}{
+		{&s.second, secondField},
+		{&s.minute, minuteField},
+		{&s.hour, hourField},
+		{&s.dom, domField},
+		{&s.month, monthField},
+		{&s.dow, dowField},
+	}
+	for i, target := range targets {
+		bits, err := parseCronField(fields[i], target.field)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q: %v", ErrInvalidCron, expr, err)
+		}
+		*target.bits = bits
+	}
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = isCronStar(fields[3])
+	s.dowStar = isCronStar(fields[5])
+	return s, nil
+}
+
+// MustParseCron 同ParseCron，表达式不合法时panic，用于注册固定的表达式
+func MustParseCron(expr string, timezone *time.Location) Schedule {
+	s, err := ParseCron(expr, timezone)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func isCronStar(field string) bool {
+	return field == "*" || field == "?"
+}
+
+// parseCronField 解析一个字段为位图
+func parseCronField(field string, f cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("bad step %q", part)
+			}
+			step = n
+		}
+
+		var from, to int
+		switch {
+		case isCronStar(rangePart):
+			from, to = f.min, f.max
+		case strings.Contains(rangePart, "-"):
+			lo, hi, _ := strings.Cut(rangePart, "-")
+			var err error
+			if from, err = f.value(lo); err != nil {
+				return 0, err
+			}
+			if to, err = f.value(hi); err != nil {
+				return 0, err
+			}
+		default:
+			var err error
+			if from, err = f.value(rangePart); err != nil {
+				return 0, err
+			}
+			to = from
+			if hasStep {
+				to = f.max
+			}
+		}
+		if from > to {
+			return 0, fmt.Errorf("bad range %q", part)
+		}
+		for v := from; v <= to; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+// value 解析字段中的单个值，支持数字和英文缩写
+func (f cronField) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("bad value %q", s)
+	}
+	if v < f.min || v > f.max {
+		return 0, fmt.Errorf("value %d out of range [%d, %d]", v, f.min, f.max)
+	}
+	return v, nil
+}
+
+// String 原始表达式
+func (s *cronSchedule) String() string {
+	return s.expr
+}
+
+// cronSearchYears 查找下一次触发时间的最大年数，超过时认为表达式不会再触发(例如2月30日)
+const cronSearchYears = 5
+
+// Next 获取after之后(不含after)的下一次触发时间，不会再触发时返回零值。
+// 夏令时结束时重复的墙上时间只在第一次出现时触发，小时字段为*时两次都触发，与cronie一致
+func (s *cronSchedule) Next(after time.Time) time.Time {
+	loc := s.timezone
+	t := after.In(loc).Truncate(time.Second).Add(time.Second)
+	limit := t.Year() + cronSearchYears
+
+	for t.Year() <= limit {
+		y, m, d := t.Date()
+		switch {
+		case s.month&(1<<uint(m)) == 0:
+			t = time.Date(y, m+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			next := time.Date(y, m, d, t.Hour()+1, 0, 0, 0, loc)
+			if !next.After(t) {
+				// 夏令时结束时重复的小时
+				next = t.Truncate(time.Hour).Add(time.Hour)
+			}
+			t = next
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Truncate(time.Minute).Add(time.Minute)
+		case s.second&(1<<uint(t.Second())) == 0:
+			t = t.Add(time.Second)
+		default:
+			if end, repeated := repeatedWallTime(t); repeated && s.hour != cronAllHours {
+				t = end
+				continue
+			}
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// cronAllHours 小时字段为*时的取值
+const cronAllHours = 1<<24 - 1
+
+// repeatedWallTime t是否为夏令时结束(时钟回拨)后第二次出现的墙上时间，是时返回重复时段的结束时刻
+func repeatedWallTime(t time.Time) (time.Time, bool) {
+	start, _ := t.ZoneBounds()
+	if start.IsZero() {
+		return time.Time{}, false
+	}
+	_, offset := t.Zone()
+	_, before := start.Add(-time.Nanosecond).Zone()
+	if before <= offset {
+		return time.Time{}, false
+	}
+	end := start.Add(time.Duration(before-offset) * time.Second)
+	return end, t.Before(end)
+}
+
+// dayMatches t的日期是否满足日与周字段
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
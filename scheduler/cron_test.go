@@ -0,0 +1,83 @@
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rgbi-git/lancet/timeutil"
+)
+
+func TestParseCron(t *testing.T) {
+	shanghai := timeutil.TimezoneShanghai
+	newYork, _ := time.LoadLocation("America/New_York")
+	at := func(loc *time.Location, y int, m time.Month, d, hour, min, sec int) time.Time {
+		return time.Date(y, m, d, hour, min, sec, 0, loc)
+	}
+
+	tests := []struct {
+		expr     string
+		loc      *time.Location
+		after    time.Time
+		expected time.Time
+	}{
+		{"0 8 * * *", shanghai, at(shanghai, 2024, 7, 28, 7, 59, 0), at(shanghai, 2024, 7, 28, 8, 0, 0)},
+		{"0 8 * * *", shanghai, at(shanghai, 2024, 7, 28, 8, 0, 0), at(shanghai, 2024, 7, 29, 8, 0, 0)},
+		{"*/15 * * * *", shanghai, at(shanghai, 2024, 7, 28, 10, 16, 0), at(shanghai, 2024, 7, 28, 10, 30, 0)},
+		{"30 0 9 * * MON-FRI", shanghai, at(shanghai, 2024, 7, 26, 10, 0, 0), at(shanghai, 2024, 7, 29, 9, 0, 30)},
+		{"0 9,18 * * sat,sun", shanghai, at(shanghai, 2024, 7, 27, 12, 0, 0), at(shanghai, 2024, 7, 27, 18, 0, 0)},
+		{"0 0 1 * MON", shanghai, at(shanghai, 2024, 7, 25, 0, 0, 0), at(shanghai, 2024, 7, 29, 0, 0, 0)},
+		{"0 0 * * 7", shanghai, at(shanghai, 2024, 7, 25, 0, 0, 0), at(shanghai, 2024, 7, 28, 0, 0, 0)},
+		{"0 12 1/10 JAN-MAR ?", shanghai, at(shanghai, 2024, 7, 28, 0, 0, 0), at(shanghai, 2025, 1, 1, 12, 0, 0)},
+		{"@monthly", shanghai, at(shanghai, 2024, 7, 28, 0, 0, 0), at(shanghai, 2024, 8, 1, 0, 0, 0)},
+		{"@hourly", shanghai, at(shanghai, 2024, 7, 28, 10, 0, 0), at(shanghai, 2024, 7, 28, 11, 0, 0)},
+		{"@every 90m", shanghai, at(shanghai, 2024, 7, 28, 10, 0, 0), at(shanghai, 2024, 7, 28, 11, 30, 0)},
+		{"0 8 * * *", time.UTC, at(shanghai, 2024, 7, 28, 10, 0, 0), at(shanghai, 2024, 7, 28, 16, 0, 0)},
+		{"0 8 * * *", nil, at(shanghai, 2024, 7, 28, 10, 0, 0), at(shanghai, 2024, 7, 28, 16, 0, 0)},
+		// 夏令时开始当天不存在的02:30被跳过
+		{"30 2 * * *", newYork, at(newYork, 2024, 3, 9, 3, 0, 0), at(newYork, 2024, 3, 11, 2, 30, 0)},
+		// 夏令时结束当天重复的01:30只触发一次
+		{"30 1 * * *", newYork, at(newYork, 2024, 11, 3, 1, 30, 0), at(newYork, 2024, 11, 4, 1, 30, 0)},
+		{"30 1 * * *", newYork, time.Date(2024, 11, 3, 5, 45, 0, 0, time.UTC), at(newYork, 2024, 11, 4, 1, 30, 0)},
+		{"30 * * * *", newYork, at(newYork, 2024, 11, 3, 1, 30, 0), time.Date(2024, 11, 3, 6, 30, 0, 0, time.UTC)},
+		{"0 0 30 2 *", shanghai, at(shanghai, 2024, 7, 28, 0, 0, 0), time.Time{}},
+	}
+	for _, test := range tests {
+		s, err := ParseCron(test.expr, test.loc)
+		if err != nil {
+			t.Errorf("ParseCron(%q) error: %v", test.expr, err)
+			continue
+		}
+		if got := s.Next(test.after); !got.Equal(test.expected) {
+			t.Errorf("ParseCron(%q).Next(%v) = %v; want %v", test.expr, test.after, got, test.expected)
+		}
+	}
+}
+
+func TestParseCronInvalid(t *testing.T) {
+	for _, expr := range []string{"", "* * *", "61 * * * *", "5-1 * * * *", "*/0 * * * *", "* * * FOO *", "@every x", "@every -1m"} {
+		if _, err := ParseCron(expr, time.UTC); !errors.Is(err, ErrInvalidCron) {
+			t.Errorf("ParseCron(%q) error = %v; want ErrInvalidCron", expr, err)
+		}
+	}
+}
+
+func TestSchedules(t *testing.T) {
+	loc := timeutil.TimezoneShanghai
+	now := time.Date(2024, 7, 28, 10, 2, 0, 0, loc)
+
+	if got := Every(time.Hour).Next(now); !got.Equal(now.Add(time.Hour)) {
+		t.Errorf("Every(1h).Next = %v; want %v", got, now.Add(time.Hour))
+	}
+	once := At(now.Add(time.Minute))
+	if got := once.Next(now); !got.Equal(now.Add(time.Minute)) {
+		t.Errorf("At.Next before = %v; want %v", got, now.Add(time.Minute))
+	}
+	if got := once.Next(now.Add(time.Minute)); !got.IsZero() {
+		t.Errorf("At.Next after = %v; want zero", got)
+	}
+	expected := timeutil.GetTime15Minute(now.Add(15*time.Minute).Unix(), loc)
+	if got := Aligned(timeutil.PeriodOf(15*time.Minute), 0, loc).Next(now); !got.Equal(expected) {
+		t.Errorf("Aligned(15m).Next = %v; want %v", got, expected)
+	}
+}
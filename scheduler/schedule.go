@@ -0,0 +1,51 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/rgbi-git/lancet/timeutil"
+)
+
+// Schedule 任务的触发计划
+type Schedule interface {
+	// Next 获取after之后(不含after)的下一次触发时间，不会再触发时返回零值
+	Next(after time.Time) time.Time
+}
+
+// ScheduleFunc 函数形式的Schedule
+type ScheduleFunc func(after time.Time) time.Time
+
+// Next 调用f(after)
+func (f ScheduleFunc) Next(after time.Time) time.Time {
+	return f(after)
+}
+
+// Every 固定间隔触发，第一次在after之后d触发
+func Every(d time.Duration) Schedule {
+	return ScheduleFunc(func(after time.Time) time.Time {
+		if d <= 0 {
+			return time.Time{}
+		}
+		return after.Add(d)
+	})
+}
+
+// At 只在指定时间触发一次
+func At(t time.Time) Schedule {
+	return ScheduleFunc(func(after time.Time) time.Time {
+		if t.After(after) {
+			return t
+		}
+		return time.Time{}
+	})
+}
+
+// Aligned 在period于timezone下的每个边界加offset处触发，例如Aligned(timeutil.PeriodOf(15*time.Minute), 0, tz)与GetTime15Minute的桶一致
+func Aligned(period timeutil.Period, offset time.Duration, timezone *time.Location) Schedule {
+	return ScheduleFunc(func(after time.Time) time.Time {
+		if period.IsZero() {
+			return time.Time{}
+		}
+		return timeutil.NextAlignedTime(after, period, offset, timezone)
+	})
+}
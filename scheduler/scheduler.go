@@ -0,0 +1,347 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/rgbi-git/lancet/timeutil"
+)
+
+var (
+	// ErrInvalidJob 任务缺少名称、触发计划或执行函数
+	ErrInvalidJob = errors.New("scheduler: invalid job")
+	// ErrJobExists 同名任务已存在
+	ErrJobExists = errors.New("scheduler: job already exists")
+	// ErrJobTimeout 任务执行超时
+	ErrJobTimeout = errors.New("scheduler: job timed out")
+	// ErrAlreadyStarted 调度器已启动
+	ErrAlreadyStarted = errors.New("scheduler: already started")
+	// ErrStopped 调度器已停止
+	ErrStopped = errors.New("scheduler: stopped")
+)
+
+// OverlapPolicy 上一次执行尚未结束时再次触发的处理方式
+type OverlapPolicy int
+
+const (
+	OverlapSkip  OverlapPolicy = iota // 跳过本次触发，记录一条Skipped的执行记录
+	OverlapQueue                      // 排队，上一次执行结束后依次执行，排队数超过Job.MaxQueue时按OverlapSkip跳过
+	OverlapAllow                      // 允许并发执行
+)
+
+// Job 定时任务
+type Job struct {
+	Name     string                          // 任务名，在调度器内唯一
+	Schedule Schedule                        // 触发计划，例如ParseCron("0 8 * * *", shanghai)、Every(time.Hour)、At(t)
+	Func     func(ctx context.Context) error // 执行函数，应在ctx结束时尽快返回
+	Overlap  OverlapPolicy                   // 重叠执行的处理方式
+	MaxQueue int                             // OverlapQueue时最多排队的触发数，<=0时为10
+	Timeout  time.Duration                   // 单次执行的超时时长，超时后取消ctx，<=0时不限
+}
+
+// Run 一次执行的记录
+type Run struct {
+	Job       string        // 任务名
+	Scheduled time.Time     // 计划触发时间
+	Start     time.Time     // 实际开始时间
+	Duration  time.Duration // 执行耗时
+	Err       error         // 执行结果，超时时包含ErrJobTimeout，panic时为*PanicError
+	Skipped   bool          // 是否因上一次执行尚未结束而跳过
+}
+
+// PanicError 任务执行时发生panic
+type PanicError struct {
+	Value any    // recover得到的值
+	Stack []byte // panic时的调用栈
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("scheduler: job panicked: %v", e.Value)
+}
+
+// Config 调度器配置
+type Config struct {
+	Clock       timeutil.Clock // 时间源，nil时使用timeutil.SystemClock
+	HistorySize int            // 每个任务保留的执行记录数，0时为100，<0时不保留
+	OnRun       func(run Run)  // 每次执行结束(或跳过)后的回调
+}
+
+const (
+	defaultHistorySize = 100
+	defaultMaxQueue    = 10
+)
+
+// Scheduler 进程内定时任务调度器
+type Scheduler struct {
+	mu      sync.Mutex
+	clock   timeutil.Clock
+	config  Config
+	jobs    map[string]*jobEntry
+	started bool
+	stopped bool
+
+	loopCtx    context.Context // 触发循环的ctx，Stop时取消
+	stopLoops  context.CancelFunc
+	runCtx     context.Context // 任务执行的ctx，Stop等待超时时取消
+	cancelRuns context.CancelFunc
+	loops      sync.WaitGroup
+	runs       sync.WaitGroup
+}
+
+// jobEntry 调度器内的任务及其状态，除job外的字段由Scheduler.mu保护
+type jobEntry struct {
+	job     Job
+	cancel  context.CancelFunc
+	running int
+	queue   []time.Time
+	history []Run
+}
+
+// New 创建调度器，调用Start后开始触发任务
+func New(config Config) *Scheduler {
+	if config.HistorySize == 0 {
+		config.HistorySize = defaultHistorySize
+	}
+	clock := config.Clock
+	if clock == nil {
+		clock = timeutil.SystemClock
+	}
+	return &Scheduler{clock: clock, config: config, jobs: make(map[string]*jobEntry)}
+}
+
+// Add 注册任务，调度器已启动时立即开始触发
+func (s *Scheduler) Add(job Job) error {
+	if job.Name == "" || job.Schedule == nil || job.Func == nil {
+		return fmt.Errorf("%w: name, schedule and func are required", ErrInvalidJob)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return ErrStopped
+	}
+	if _, ok := s.jobs[job.Name]; ok {
+		return fmt.Errorf("%w: %s", ErrJobExists, job.Name)
+	}
+	e := &jobEntry{job: job}
+	s.jobs[job.Name] = e
+	if s.started {
+		s.startLoop(e)
+	}
+	return nil
+}
+
+// Remove 移除任务，不再触发，正在进行的执行不受影响。任务不存在时返回false
+func (s *Scheduler) Remove(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.jobs[name]
+	if !ok {
+		return false
+	}
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.queue = nil
+	delete(s.jobs, name)
+	return true
+}
+
+// Jobs 已注册的任务名，按名称排序
+func (s *Scheduler) Jobs() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	names := make([]string, 0, len(s.jobs))
+	for name := range s.jobs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// History 任务最近的执行记录，按时间先后排列
+func (s *Scheduler) History(name string) []Run {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.jobs[name]
+	if !ok {
+		return nil
+	}
+	return append([]Run(nil), e.history...)
+}
+
+// Start 开始触发任务，不阻塞。ctx结束时停止触发并取消正在执行的任务
+func (s *Scheduler) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return ErrStopped
+	}
+	if s.started {
+		return ErrAlreadyStarted
+	}
+	s.started = true
+	s.loopCtx, s.stopLoops = context.WithCancel(ctx)
+	s.runCtx, s.cancelRuns = context.WithCancel(ctx)
+	for _, e := range s.jobs {
+		s.startLoop(e)
+	}
+	return nil
+}
+
+// Stop 停止触发任务并丢弃排队中的执行，等待正在进行的执行结束。
+// ctx先结束时取消正在执行的任务并返回ctx.Err()
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return nil
+	}
+	s.stopped = true
+	if !s.started {
+		s.mu.Unlock()
+		return nil
+	}
+	s.stopLoops()
+	for _, e := range s.jobs {
+		e.queue = nil
+	}
+	s.mu.Unlock()
+
+	s.loops.Wait()
+	done := make(chan struct{})
+	go func() {
+		s.runs.Wait()
+		close(done)
+	}()
+	defer s.cancelRuns()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// startLoop 启动任务的触发循环，调用方需持有锁
+func (s *Scheduler) startLoop(e *jobEntry) {
+	var ctx context.Context
+	ctx, e.cancel = context.WithCancel(s.loopCtx)
+	s.loops.Add(1)
+	go s.loop(ctx, e)
+}
+
+// loop 按触发计划等待并分发执行，错过的触发时间不会补发
+func (s *Scheduler) loop(ctx context.Context, e *jobEntry) {
+	defer s.loops.Done()
+	next := e.job.Schedule.Next(s.clock.Now())
+	for !next.IsZero() {
+		timer := s.clock.NewTimer(next.Sub(s.clock.Now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+		s.dispatch(e, next)
+
+		scheduled := next
+		next = e.job.Schedule.Next(scheduled)
+		if now := s.clock.Now(); !next.IsZero() && next.Before(now) {
+			next = e.job.Schedule.Next(now)
+		}
+	}
+}
+
+// dispatch 按重叠策略执行、排队或跳过一次触发
+func (s *Scheduler) dispatch(e *jobEntry, scheduled time.Time) {
+	s.mu.Lock()
+	if s.stopped {
+		s.mu.Unlock()
+		return
+	}
+	if e.running > 0 {
+		maxQueue := e.job.MaxQueue
+		if maxQueue <= 0 {
+			maxQueue = defaultMaxQueue
+		}
+		switch {
+		case e.job.Overlap == OverlapQueue && len(e.queue) < maxQueue:
+			e.queue = append(e.queue, scheduled)
+			s.mu.Unlock()
+			return
+		case e.job.Overlap == OverlapQueue, e.job.Overlap == OverlapSkip:
+			s.mu.Unlock()
+			s.record(e, Run{Job: e.job.Name, Scheduled: scheduled, Start: s.clock.Now(), Skipped: true})
+			return
+		}
+	}
+	e.running++
+	s.runs.Add(1)
+	s.mu.Unlock()
+	go s.execute(e, scheduled)
+}
+
+// execute 执行一次任务，排队策略下继续执行排队中的触发
+func (s *Scheduler) execute(e *jobEntry, scheduled time.Time) {
+	defer s.runs.Done()
+	for {
+		s.record(e, s.runJob(e.job, scheduled))
+
+		s.mu.Lock()
+		if len(e.queue) == 0 || s.stopped {
+			e.running--
+			s.mu.Unlock()
+			return
+		}
+		scheduled = e.queue[0]
+		e.queue = e.queue[1:]
+		s.mu.Unlock()
+	}
+}
+
+// runJob 执行任务函数，处理超时和panic
+func (s *Scheduler) runJob(job Job, scheduled time.Time) (run Run) {
+	ctx, cancel := context.WithCancelCause(s.runCtx)
+	defer cancel(nil)
+	if job.Timeout > 0 {
+		timer := s.clock.AfterFunc(job.Timeout, func() { cancel(ErrJobTimeout) })
+		defer timer.Stop()
+	}
+
+	run = Run{Job: job.Name, Scheduled: scheduled, Start: s.clock.Now()}
+	defer func() {
+		if v := recover(); v != nil {
+			run.Err = &PanicError{Value: v, Stack: debug.Stack()}
+		}
+		run.Duration = s.clock.Since(run.Start)
+		if errors.Is(context.Cause(ctx), ErrJobTimeout) && !errors.Is(run.Err, ErrJobTimeout) {
+			if run.Err == nil {
+				run.Err = ErrJobTimeout
+			} else {
+				run.Err = fmt.Errorf("%w: %w", ErrJobTimeout, run.Err)
+			}
+		}
+	}()
+	run.Err = job.Func(ctx)
+	return run
+}
+
+// record 保存执行记录并调用OnRun
+func (s *Scheduler) record(e *jobEntry, run Run) {
+	if size := s.config.HistorySize; size > 0 {
+		s.mu.Lock()
+		e.history = append(e.history, run)
+		if len(e.history) > size {
+			e.history = append(e.history[:0], e.history[len(e.history)-size:]...)
+		}
+		s.mu.Unlock()
+	}
+	if s.config.OnRun != nil {
+		s.config.OnRun(run)
+	}
+}
@@ -0,0 +1,237 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rgbi-git/lancet/timeutil"
+)
+
+func newTestScheduler(t *testing.T, historySize int) (*Scheduler, *timeutil.FakeClock, <-chan Run) {
+	t.Helper()
+	loc := timeutil.TimezoneShanghai
+	clock := timeutil.NewFakeClock(time.Date(2024, 7, 28, 7, 59, 0, 0, loc))
+	runs := make(chan Run, 16)
+	s := New(Config{Clock: clock, HistorySize: historySize, OnRun: func(run Run) { runs <- run }})
+	t.Cleanup(func() { _ = s.Stop(context.Background()) })
+	return s, clock, runs
+}
+
+func TestSchedulerCron(t *testing.T) {
+	s, clock, runs := newTestScheduler(t, 0)
+	loc := timeutil.TimezoneShanghai
+	err := s.Add(Job{
+		Name:     "daily-report",
+		Schedule: MustParseCron("0 8 * * *", loc),
+		Func:     func(ctx context.Context) error { return nil },
+	})
+	if err != nil {
+		t.Fatalf("Add() error: %v", err)
+	}
+	if err := s.Add(Job{Name: "daily-report", Schedule: Every(time.Hour), Func: func(context.Context) error { return nil }}); !errors.Is(err, ErrJobExists) {
+		t.Errorf("Add(duplicate) = %v; want ErrJobExists", err)
+	}
+	if err := s.Add(Job{Name: "invalid"}); !errors.Is(err, ErrInvalidJob) {
+		t.Errorf("Add(invalid) = %v; want ErrInvalidJob", err)
+	}
+	if err := s.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error: %v", err)
+	}
+	if err := s.Start(context.Background()); !errors.Is(err, ErrAlreadyStarted) {
+		t.Errorf("Start() twice = %v; want ErrAlreadyStarted", err)
+	}
+
+	for day := 28; day <= 29; day++ {
+		clock.BlockUntil(1)
+		clock.AdvanceToNext()
+		run := <-runs
+		expected := time.Date(2024, 7, day, 8, 0, 0, 0, loc)
+		if !run.Scheduled.Equal(expected) || run.Err != nil || run.Skipped {
+			t.Errorf("run = %+v; want scheduled at %v", run, expected)
+		}
+	}
+	if history := s.History("daily-report"); len(history) != 2 {
+		t.Errorf("History() = %v; want 2 runs", history)
+	}
+	if jobs := s.Jobs(); len(jobs) != 1 || jobs[0] != "daily-report" {
+		t.Errorf("Jobs() = %v; want [daily-report]", jobs)
+	}
+	if !s.Remove("daily-report") || s.Remove("daily-report") {
+		t.Errorf("Remove() should succeed exactly once")
+	}
+}
+
+func TestSchedulerOverlap(t *testing.T) {
+	tests := []struct {
+		policy   OverlapPolicy
+		expected []bool // 每条执行记录是否Skipped
+	}{
+		{OverlapSkip, []bool{true, false}},
+		{OverlapQueue, []bool{false, false}},
+		{OverlapAllow, []bool{false, false}},
+	}
+	for _, test := range tests {
+		s, clock, runs := newTestScheduler(t, 0)
+		started := make(chan struct{}, 2)
+		release := make(chan struct{}, 2)
+		_ = s.Add(Job{
+			Name:     "sync",
+			Schedule: Every(time.Minute),
+			Overlap:  test.policy,
+			Func: func(ctx context.Context) error {
+				started <- struct{}{}
+				<-release
+				return nil
+			},
+		})
+		_ = s.Start(context.Background())
+
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		<-started
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		if test.policy == OverlapAllow {
+			<-started
+		}
+		if test.policy == OverlapSkip {
+			if run := <-runs; !run.Skipped {
+				t.Errorf("policy %d: run = %+v; want skipped", test.policy, run)
+			}
+		}
+		release <- struct{}{}
+		release <- struct{}{}
+
+		for _, skipped := range test.expected {
+			if skipped {
+				continue
+			}
+			if run := <-runs; run.Skipped || run.Err != nil {
+				t.Errorf("policy %d: run = %+v; want success", test.policy, run)
+			}
+		}
+		if history := s.History("sync"); len(history) != len(test.expected) {
+			t.Errorf("policy %d: History() = %+v; want %d runs", test.policy, history, len(test.expected))
+		}
+		_ = s.Stop(context.Background())
+	}
+}
+
+func TestSchedulerMaxQueue(t *testing.T) {
+	s, clock, runs := newTestScheduler(t, 0)
+	started := make(chan struct{}, 3)
+	release := make(chan struct{}, 3)
+	_ = s.Add(Job{
+		Name:     "sync",
+		Schedule: Every(time.Minute),
+		Overlap:  OverlapQueue,
+		MaxQueue: 1,
+		Func: func(ctx context.Context) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		},
+	})
+	_ = s.Start(context.Background())
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	<-started
+	for i := 0; i < 2; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+	}
+	// 第3次触发时队列已满，被跳过
+	if run := <-runs; !run.Skipped {
+		t.Errorf("run = %+v; want skipped", run)
+	}
+	release <- struct{}{}
+	release <- struct{}{}
+	for i := 0; i < 2; i++ {
+		if run := <-runs; run.Skipped || run.Err != nil {
+			t.Errorf("run = %+v; want success", run)
+		}
+	}
+}
+
+func TestSchedulerTimeoutAndPanic(t *testing.T) {
+	s, clock, runs := newTestScheduler(t, 0)
+	_ = s.Add(Job{
+		Name:     "slow",
+		Schedule: Every(time.Hour),
+		Timeout:  30 * time.Second,
+		Func: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+	_ = s.Start(context.Background())
+
+	clock.BlockUntil(1)
+	clock.Advance(time.Hour)
+	clock.BlockUntil(2)
+	clock.Advance(30 * time.Second)
+	if run := <-runs; !errors.Is(run.Err, ErrJobTimeout) || run.Duration != 30*time.Second {
+		t.Errorf("timed out run = %+v; want ErrJobTimeout after 30s", run)
+	}
+
+	s, clock, runs = newTestScheduler(t, 0)
+	_ = s.Start(context.Background())
+	_ = s.Add(Job{
+		Name:     "panic",
+		Schedule: At(clock.Now().Add(time.Minute)),
+		Func:     func(ctx context.Context) error { panic("boom") },
+	})
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	var panicErr *PanicError
+	if run := <-runs; !errors.As(run.Err, &panicErr) || panicErr.Value != "boom" {
+		t.Errorf("panicked run = %+v; want *PanicError", run)
+	}
+}
+
+func TestSchedulerStop(t *testing.T) {
+	s, clock, runs := newTestScheduler(t, 2)
+	started := make(chan struct{}, 1)
+	_ = s.Add(Job{
+		Name:     "job",
+		Schedule: Every(time.Minute),
+		Func: func(ctx context.Context) error {
+			if clock.Now().Sub(time.Date(2024, 7, 28, 7, 59, 0, 0, clock.Now().Location())) < 3*time.Minute {
+				return nil
+			}
+			started <- struct{}{}
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+	_ = s.Start(context.Background())
+	for i := 0; i < 3; i++ {
+		clock.BlockUntil(1)
+		clock.Advance(time.Minute)
+		if i < 2 {
+			<-runs
+		}
+	}
+	<-started
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := s.Stop(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Stop(canceled) = %v; want context.Canceled", err)
+	}
+	if run := <-runs; !errors.Is(run.Err, context.Canceled) {
+		t.Errorf("run after Stop = %+v; want context.Canceled", run)
+	}
+	if history := s.History("job"); len(history) != 2 || !history[1].Scheduled.Equal(clock.Now()) {
+		t.Errorf("History() = %+v; want last 2 runs", history)
+	}
+	if err := s.Stop(context.Background()); err != nil {
+		t.Errorf("Stop() twice = %v; want nil", err)
+	}
+	if err := s.Add(Job{Name: "late", Schedule: Every(time.Minute), Func: func(context.Context) error { return nil }}); !errors.Is(err, ErrStopped) {
+		t.Errorf("Add() after Stop = %v; want ErrStopped", err)
+	}
+}
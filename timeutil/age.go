@@ -0,0 +1,156 @@
+package timeutil
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// LeapDayPolicy 目标月份没有对应日期时的处理策略，典型场景是2月29日在平年的周年日
+type LeapDayPolicy int
+
+const (
+	LeapDayFeb28 LeapDayPolicy = iota // 取当月最后一天，2月29日在平年按2月28日计算
+	LeapDayMar1                       // 顺延到下月1日，2月29日在平年按3月1日计算
+)
+
+// DateDiff 两个日期之间的自然年、月、日差值，from晚于to时各分量均为负数
+type DateDiff struct {
+	Years  int
+	Months int
+	Days   int
+}
+
+// TotalMonths 差值折算的总月数，不含Days
+func (d DateDiff) TotalMonths() int {
+	return d.Years*12 + d.Months
+}
+
+// String 英文描述，例如"3 years 2 months 5 days"
+func (d DateDiff) String() string {
+	sign, diff := d.abs()
+	unit := func(n int, name string) string {
+		if n == 1 {
+			return fmt.Sprintf("%d %s", n, name)
+		}
+		return fmt.Sprintf("%d %ss", n, name)
+	}
+	var parts []string
+	if diff.Years != 0 {
+		parts = append(parts, unit(diff.Years, "year"))
+	}
+	if diff.Months != 0 {
+		parts = append(parts, unit(diff.Months, "month"))
+	}
+	if diff.Days != 0 || len(parts) == 0 {
+		parts = append(parts, unit(diff.Days, "day"))
+	}
+	return sign + strings.Join(parts, " ")
+}
+
+// ChineseString 中文描述，例如"3年2个月5天"
+func (d DateDiff) ChineseString() string {
+	sign, diff := d.abs()
+	var b strings.Builder
+	b.WriteString(sign)
+	if diff.Years != 0 {
+		fmt.Fprintf(&b, "%d年", diff.Years)
+	}
+	if diff.Months != 0 {
+		fmt.Fprintf(&b, "%d个月", diff.Months)
+	}
+	if diff.Days != 0 || b.Len() == len(sign) {
+		fmt.Fprintf(&b, "%d天", diff.Days)
+	}
+	return b.String()
+}
+
+func (d DateDiff) abs() (string, DateDiff) {
+	if d.Years < 0 || d.Months < 0 || d.Days < 0 {
+		return "-", DateDiff{Years: -d.Years, Months: -d.Months, Days: -d.Days}
+	}
+	return "", d
+}
+
+// CalendarDiff 计算两个日期之间相差的年、月、日，只比较日期部分，
+// policy决定起始日在目标月份不存在时(如1月31日、2月29日)按月末还是次月1日计算
+func CalendarDiff(from, to time.Time, policy LeapDayPolicy) DateDiff {
+	fy, fm, fd := from.Date()
+	ty, tm, td := to.Date()
+	toDate := civilDays(ty, tm, td)
+	if toDate < civilDays(fy, fm, fd) {
+		diff := CalendarDiff(to, from, policy)
+		return DateDiff{Years: -diff.Years, Months: -diff.Months, Days: -diff.Days}
+	}
+
+	months := (ty-fy)*12 + int(tm-fm)
+	for months > 0 && civilDays(addCivilMonths(fy, fm, fd, months, policy)) > toDate {
+		months--
+	}
+	days := toDate - civilDays(addCivilMonths(fy, fm, fd, months, policy))
+	return DateDiff{Years: months / 12, Months: months % 12, Days: days}
+}
+
+// Age 计算出生日期birth在on当天的周岁
+func Age(birth, on time.Time, policy LeapDayPolicy) int {
+	return CalendarDiff(birth, on, policy).Years
+}
+
+// Anniversary 获取date在year年的周年日，时间为date所在时区的零点
+func Anniversary(date time.Time, year int, policy LeapDayPolicy) time.Time {
+	y, m, d := date.Date()
+	ay, am, ad := addCivilMonths(y, m, d, (year-y)*12, policy)
+	return time.Date(ay, am, ad, 0, 0, 0, 0, date.Location())
+}
+
+// NextAnniversary 获取date在after当天或之后的第一个周年日，例如下一个生日
+func NextAnniversary(date, after time.Time, policy LeapDayPolicy) time.Time {
+	ay, am, ad := after.Date()
+	target := civilDays(ay, am, ad)
+	year := ay
+	if year <= date.Year() {
+		year = date.Year() + 1
+	}
+	for {
+		anniversary := Anniversary(date, year, policy)
+		if civilDays(anniversary.Date()) >= target {
+			return anniversary
+		}
+		year++
+	}
+}
+
+// daysInMonth 获取某年某月的天数
+func daysInMonth(year int, month time.Month) int {
+	switch month {
+	case time.February:
+		if IsLeapYear(year) {
+			return 29
+		}
+		return 28
+	case time.April, time.June, time.September, time.November:
+		return 30
+	}
+	return 31
+}
+
+// addCivilMonths 日期加减月数，目标月份没有对应日期时按policy处理
+func addCivilMonths(year int, month time.Month, day int, months int, policy LeapDayPolicy) (int, time.Month, int) {
+	total := year*12 + int(month) - 1 + months
+	y, m := total/12, time.Month(total%12+1)
+	if total < 0 && total%12 != 0 {
+		y, m = total/12-1, time.Month(total%12+13)
+	}
+	if last := daysInMonth(y, m); day > last {
+		if policy == LeapDayMar1 {
+			return addCivilMonths(y, m, 1, 1, policy)
+		}
+		day = last
+	}
+	return y, m, day
+}
+
+// civilDays 日期距1970-01-01的天数
+func civilDays(year int, month time.Month, day int) int {
+	return int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
@@ -0,0 +1,95 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func testDate(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, TimezoneShanghai)
+}
+
+func TestCalendarDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		policy   LeapDayPolicy
+		expected DateDiff
+	}{
+		{"tenure", testDate(2021, 5, 23), testDate(2024, 7, 28), LeapDayFeb28, DateDiff{3, 2, 5}},
+		{"same day", testDate(2024, 7, 28), testDate(2024, 7, 28), LeapDayFeb28, DateDiff{0, 0, 0}},
+		{"month end clamp", testDate(2024, 1, 31), testDate(2024, 2, 29), LeapDayFeb28, DateDiff{0, 1, 0}},
+		{"month end overflow", testDate(2024, 1, 31), testDate(2024, 2, 29), LeapDayMar1, DateDiff{0, 0, 29}},
+		{"leap day feb28", testDate(2020, 2, 29), testDate(2023, 2, 28), LeapDayFeb28, DateDiff{3, 0, 0}},
+		{"leap day mar1", testDate(2020, 2, 29), testDate(2023, 2, 28), LeapDayMar1, DateDiff{2, 11, 30}},
+		{"negative", testDate(2024, 7, 28), testDate(2024, 6, 27), LeapDayFeb28, DateDiff{0, -1, -1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CalendarDiff(tt.from, tt.to, tt.policy); got != tt.expected {
+				t.Errorf("CalendarDiff() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDateDiffString(t *testing.T) {
+	tests := []struct {
+		diff    DateDiff
+		english string
+		chinese string
+	}{
+		{DateDiff{3, 2, 5}, "3 years 2 months 5 days", "3年2个月5天"},
+		{DateDiff{1, 0, 1}, "1 year 1 day", "1年1天"},
+		{DateDiff{0, 0, 0}, "0 days", "0天"},
+		{DateDiff{0, -1, -1}, "-1 month 1 day", "-1个月1天"},
+	}
+	for _, test := range tests {
+		if result := test.diff.String(); result != test.english {
+			t.Errorf("%+v.String() = %q; want %q", test.diff, result, test.english)
+		}
+		if result := test.diff.ChineseString(); result != test.chinese {
+			t.Errorf("%+v.ChineseString() = %q; want %q", test.diff, result, test.chinese)
+		}
+	}
+}
+
+func TestAge(t *testing.T) {
+	tests := []struct {
+		birth    time.Time
+		on       time.Time
+		policy   LeapDayPolicy
+		expected int
+	}{
+		{testDate(1990, 7, 29), testDate(2024, 7, 28), LeapDayFeb28, 33},
+		{testDate(1990, 7, 28), testDate(2024, 7, 28), LeapDayFeb28, 34},
+		{testDate(2004, 2, 29), testDate(2023, 2, 28), LeapDayFeb28, 19},
+		{testDate(2004, 2, 29), testDate(2023, 2, 28), LeapDayMar1, 18},
+	}
+	for _, test := range tests {
+		if result := Age(test.birth, test.on, test.policy); result != test.expected {
+			t.Errorf("Age(%v, %v, %v) = %d; want %d", test.birth, test.on, test.policy, result, test.expected)
+		}
+	}
+}
+
+func TestNextAnniversary(t *testing.T) {
+	tests := []struct {
+		date     time.Time
+		after    time.Time
+		policy   LeapDayPolicy
+		expected time.Time
+	}{
+		{testDate(1990, 7, 28), testDate(2024, 7, 28), LeapDayFeb28, testDate(2024, 7, 28)},
+		{testDate(1990, 7, 28), testDate(2024, 7, 29), LeapDayFeb28, testDate(2025, 7, 28)},
+		{testDate(2020, 2, 29), testDate(2024, 7, 1), LeapDayFeb28, testDate(2025, 2, 28)},
+		{testDate(2020, 2, 29), testDate(2024, 7, 1), LeapDayMar1, testDate(2025, 3, 1)},
+		{testDate(2020, 2, 29), testDate(2027, 3, 2), LeapDayMar1, testDate(2028, 2, 29)},
+	}
+	for _, test := range tests {
+		if result := NextAnniversary(test.date, test.after, test.policy); !result.Equal(test.expected) {
+			t.Errorf("NextAnniversary(%v, %v, %v) = %v; want %v", test.date, test.after, test.policy, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package timeutil
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// AlignedTickerConfig 对齐定时器配置
+type AlignedTickerConfig struct {
+	Period   Period         // 触发粒度，例如PeriodOf(5*time.Minute)在每个与GetTime5Minute一致的边界触发
+	Offset   time.Duration  // 相对边界的偏移，例如整点后30秒
+	Timezone *time.Location // 边界对齐的时区，为nil时为UTC
+	Jitter   time.Duration  // 每次触发额外随机延迟[0, Jitter)，用于分散多个实例的负载
+	CatchUp  bool           // 接收方处理不及时错过边界时，是否补发错过的每个边界；为false时跳过，只等待下一个边界
+	Clock    Clock          // 时间源，nil时使用SystemClock
+}
+
+// AlignedTicker 在时钟边界触发的定时器，与time.Ticker不同，触发时间不会随处理耗时漂移
+type AlignedTicker struct {
+	// C 每次触发时发送对应的边界时间(含Offset，不含Jitter)，定时器停止后关闭
+	C <-chan time.Time
+
+	cancel context.CancelFunc
+	done   chan struct{}
+	once   sync.Once
+}
+
+// NewAlignedTicker 创建对齐定时器，ctx结束或调用Stop时停止。Period为零值时C立即关闭
+func NewAlignedTicker(ctx context.Context, config AlignedTickerConfig) *AlignedTicker {
+	ctx, cancel := context.WithCancel(ctx)
+	c := make(chan time.Time)
+	t := &AlignedTicker{C: c, cancel: cancel, done: make(chan struct{})}
+	go t.run(ctx, config, c)
+	return t
+}
+
+// Stop 停止定时器并等待内部goroutine退出，可重复调用
+func (t *AlignedTicker) Stop() {
+	t.once.Do(t.cancel)
+	<-t.done
+}
+
+func (t *AlignedTicker) run(ctx context.Context, config AlignedTickerConfig, c chan<- time.Time) {
+	defer close(t.done)
+	defer close(c)
+	if config.Period.IsZero() {
+		return
+	}
+	clock := orSystemClock(config.Clock)
+	timezone := config.Timezone
+	if timezone == nil {
+		timezone = TimezoneUtc
+	}
+
+	next := NextAlignedTime(clock.Now(), config.Period, config.Offset, timezone)
+	for {
+		wait := next.Sub(clock.Now())
+		if config.Jitter > 0 {
+			wait += time.Duration(rand.Int63n(int64(config.Jitter)))
+		}
+		if err := sleepContext(ctx, clock, wait); err != nil {
+			return
+		}
+		select {
+		case c <- next:
+		case <-ctx.Done():
+			return
+		}
+		if config.CatchUp {
+			next = config.Period.Next(next.Add(-config.Offset), timezone).Add(config.Offset)
+		} else {
+			next = NextAlignedTime(clock.Now(), config.Period, config.Offset, timezone)
+		}
+	}
+}
+
+// NextAlignedTime 获取t之后(不含t)的第一个对齐边界，边界为period在timezone下的周期开始时间加offset
+func NextAlignedTime(t time.Time, period Period, offset time.Duration, timezone *time.Location) time.Time {
+	if period.IsZero() {
+		return t
+	}
+	start := period.Start(t.Add(-offset), timezone)
+	for !start.Add(offset).After(t) {
+		start = period.Next(start, timezone)
+	}
+	return start.Add(offset)
+}
@@ -0,0 +1,97 @@
+package timeutil
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNextAlignedTime(t *testing.T) {
+	loc := getTestTimezone()
+	at := func(hour, min, sec int) time.Time {
+		return time.Date(2024, 7, 28, hour, min, sec, 0, loc)
+	}
+	tests := []struct {
+		t        time.Time
+		period   Period
+		offset   time.Duration
+		expected time.Time
+	}{
+		{at(10, 2, 0), PeriodOf(5 * time.Minute), 0, at(10, 5, 0)},
+		{at(10, 5, 0), PeriodOf(5 * time.Minute), 0, at(10, 10, 0)},
+		{at(10, 2, 0), PeriodOf(15 * time.Minute), 0, at(10, 15, 0)},
+		{at(10, 0, 10), PeriodOf(time.Hour), 30 * time.Second, at(10, 0, 30)},
+		{at(10, 0, 40), PeriodOf(time.Hour), 30 * time.Second, at(11, 0, 30)},
+		{at(23, 30, 0), DailyPeriod(), 8 * time.Hour, time.Date(2024, 7, 29, 8, 0, 0, 0, loc)},
+	}
+	for _, test := range tests {
+		if got := NextAlignedTime(test.t, test.period, test.offset, loc); !got.Equal(test.expected) {
+			t.Errorf("NextAlignedTime(%v, %v) = %v; want %v", test.t, test.offset, got, test.expected)
+		}
+	}
+
+	bucket := GetTime5Minute(at(10, 7, 0).Unix(), loc)
+	if got := NextAlignedTime(at(10, 2, 0), PeriodOf(5*time.Minute), 0, loc); !got.Equal(bucket) {
+		t.Errorf("NextAlignedTime should match GetTime5Minute bucket %v, got %v", bucket, got)
+	}
+}
+
+func TestAlignedTicker(t *testing.T) {
+	loc := getTestTimezone()
+	at := func(min int) time.Time {
+		return time.Date(2024, 7, 28, 10, min, 0, 0, loc)
+	}
+
+	for _, catchUp := range []bool{false, true} {
+		clock := NewFakeClock(at(2))
+		ticker := NewAlignedTicker(context.Background(), AlignedTickerConfig{
+			Period:   PeriodOf(5 * time.Minute),
+			Timezone: loc,
+			CatchUp:  catchUp,
+			Clock:    clock,
+		})
+
+		clock.BlockUntil(1)
+		clock.Advance(13 * time.Minute)
+		expected := []time.Time{at(5), at(20)}
+		if catchUp {
+			expected = []time.Time{at(5), at(10), at(15), at(20)}
+		}
+		for i, want := range expected {
+			if i > 0 && want.After(clock.Now()) {
+				clock.BlockUntil(1)
+				clock.AdvanceTo(want)
+			}
+			if got := <-ticker.C; !got.Equal(want) {
+				t.Errorf("catchUp=%v tick #%d = %v; want %v", catchUp, i, got, want)
+			}
+		}
+
+		ticker.Stop()
+		ticker.Stop()
+		if _, ok := <-ticker.C; ok {
+			t.Errorf("catchUp=%v C not closed after Stop", catchUp)
+		}
+	}
+}
+
+func TestAlignedTickerContext(t *testing.T) {
+	clock := NewFakeClock(fixedTime(getTestTimezone()))
+	ctx, cancel := context.WithCancel(context.Background())
+	ticker := NewAlignedTicker(ctx, AlignedTickerConfig{
+		Period:   PeriodOf(time.Hour),
+		Timezone: getTestTimezone(),
+		Jitter:   time.Second,
+		Clock:    clock,
+	})
+	clock.BlockUntil(1)
+	cancel()
+	if _, ok := <-ticker.C; ok {
+		t.Errorf("C not closed after ctx canceled")
+	}
+
+	empty := NewAlignedTicker(context.Background(), AlignedTickerConfig{Clock: clock})
+	if _, ok := <-empty.C; ok {
+		t.Errorf("C not closed for zero Period")
+	}
+}
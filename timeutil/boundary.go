@@ -0,0 +1,110 @@
+package timeutil
+
+import (
+	"time"
+)
+
+// StartOfDay t在timezone下所在日的零点；零点因夏令时不存在时为当天最早的时刻，例如圣保罗2018-11-04的01:00
+func StartOfDay(t time.Time, timezone *time.Location) time.Time {
+	y, m, d := t.In(timezone).Date()
+	return startOfDate(y, m, d, timezone)
+}
+
+// startOfDate y年m月d日在timezone下最早的时刻，m、d超出范围时与time.Date一样顺延
+func startOfDate(y int, m time.Month, d int, timezone *time.Location) time.Time {
+	y, m, d = time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Date()
+	start := time.Date(y, m, d, 0, 0, 0, 0, timezone)
+	if sy, sm, sd := start.Date(); sy == y && sm == m && sd == d {
+		return start
+	}
+	// 零点因夏令时被跳过，time.Date落在了前一天，当天从时区切换的时刻开始
+	_, end := start.ZoneBounds()
+	return end
+}
+
+// EndOfDay t在timezone下所在日的最后一纳秒，即次日零点减1纳秒，例如23:59:59.999999999
+func EndOfDay(t time.Time, timezone *time.Location) time.Time {
+	return DayOf(t, timezone).End.Add(-time.Nanosecond)
+}
+
+// DayOf t在timezone下所在日的区间 [当天零点, 次日零点)，夏令时切换日的时长为23或25小时
+func DayOf(t time.Time, timezone *time.Location) Interval {
+	start := StartOfDay(t, timezone)
+	y, m, d := start.Date()
+	return Interval{Start: start, End: startOfDate(y, m, d+1, timezone)}
+}
+
+// StartOfDayUnix t在timezone下所在日零点的秒级时间戳
+func StartOfDayUnix(t time.Time, timezone *time.Location) int64 {
+	return StartOfDay(t, timezone).Unix()
+}
+
+// EndOfDayUnix t在timezone下所在日最后一秒的秒级时间戳，即23:59:59
+func EndOfDayUnix(t time.Time, timezone *time.Location) int64 {
+	return EndOfDay(t, timezone).Unix()
+}
+
+// StartOfWeek t在timezone下所在周的第一天零点，weekStart为每周的第一天，例如time.Monday
+func StartOfWeek(t time.Time, weekStart time.Weekday, timezone *time.Location) time.Time {
+	return WeeklyPeriod(weekStart).Start(t, timezone)
+}
+
+// EndOfWeek t在timezone下所在周的最后一纳秒
+func EndOfWeek(t time.Time, weekStart time.Weekday, timezone *time.Location) time.Time {
+	return WeekOf(t, weekStart, timezone).End.Add(-time.Nanosecond)
+}
+
+// WeekOf t在timezone下所在周的区间 [本周第一天零点, 下周第一天零点)
+func WeekOf(t time.Time, weekStart time.Weekday, timezone *time.Location) Interval {
+	return periodOf(WeeklyPeriod(weekStart), t, timezone)
+}
+
+// StartOfMonth t在timezone下所在月第一天的零点
+func StartOfMonth(t time.Time, timezone *time.Location) time.Time {
+	return MonthlyPeriod().Start(t, timezone)
+}
+
+// EndOfMonth t在timezone下所在月的最后一纳秒
+func EndOfMonth(t time.Time, timezone *time.Location) time.Time {
+	return MonthOf(t, timezone).End.Add(-time.Nanosecond)
+}
+
+// MonthOf t在timezone下所在月的区间 [本月1日零点, 下月1日零点)
+func MonthOf(t time.Time, timezone *time.Location) Interval {
+	return periodOf(MonthlyPeriod(), t, timezone)
+}
+
+// StartOfQuarter t在timezone下所在季度第一天的零点
+func StartOfQuarter(t time.Time, timezone *time.Location) time.Time {
+	return QuarterlyPeriod().Start(t, timezone)
+}
+
+// EndOfQuarter t在timezone下所在季度的最后一纳秒
+func EndOfQuarter(t time.Time, timezone *time.Location) time.Time {
+	return QuarterOf(t, timezone).End.Add(-time.Nanosecond)
+}
+
+// QuarterOf t在timezone下所在季度的区间 [本季度第一天零点, 下季度第一天零点)
+func QuarterOf(t time.Time, timezone *time.Location) Interval {
+	return periodOf(QuarterlyPeriod(), t, timezone)
+}
+
+// StartOfYear t在timezone下所在年1月1日的零点
+func StartOfYear(t time.Time, timezone *time.Location) time.Time {
+	return YearlyPeriod().Start(t, timezone)
+}
+
+// EndOfYear t在timezone下所在年的最后一纳秒
+func EndOfYear(t time.Time, timezone *time.Location) time.Time {
+	return YearOf(t, timezone).End.Add(-time.Nanosecond)
+}
+
+// YearOf t在timezone下所在年的区间 [本年1月1日零点, 次年1月1日零点)
+func YearOf(t time.Time, timezone *time.Location) Interval {
+	return periodOf(YearlyPeriod(), t, timezone)
+}
+
+// periodOf t所在自然周期的区间
+func periodOf(p Period, t time.Time, timezone *time.Location) Interval {
+	return Interval{Start: p.Start(t, timezone), End: p.Next(t, timezone)}
+}
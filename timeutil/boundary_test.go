@@ -0,0 +1,103 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStartEndOfPeriods(t *testing.T) {
+	loc := getTestTimezone()
+	tm := fixedTime(loc) // 2024-07-28 10:15:30 周日
+	layout := "2006-01-02 15:04:05.999999999"
+	tests := []struct {
+		name     string
+		got      time.Time
+		expected string
+	}{
+		{"StartOfDay", StartOfDay(tm, loc), "2024-07-28 00:00:00"},
+		{"EndOfDay", EndOfDay(tm, loc), "2024-07-28 23:59:59.999999999"},
+		{"StartOfWeek", StartOfWeek(tm, time.Monday, loc), "2024-07-22 00:00:00"},
+		{"EndOfWeek", EndOfWeek(tm, time.Monday, loc), "2024-07-28 23:59:59.999999999"},
+		{"StartOfWeek(Sunday)", StartOfWeek(tm, time.Sunday, loc), "2024-07-28 00:00:00"},
+		{"StartOfMonth", StartOfMonth(tm, loc), "2024-07-01 00:00:00"},
+		{"EndOfMonth", EndOfMonth(tm, loc), "2024-07-31 23:59:59.999999999"},
+		{"EndOfMonth(Feb)", EndOfMonth(time.Date(2024, 2, 10, 0, 0, 0, 0, loc), loc), "2024-02-29 23:59:59.999999999"},
+		{"StartOfQuarter", StartOfQuarter(tm, loc), "2024-07-01 00:00:00"},
+		{"EndOfQuarter", EndOfQuarter(tm, loc), "2024-09-30 23:59:59.999999999"},
+		{"StartOfYear", StartOfYear(tm, loc), "2024-01-01 00:00:00"},
+		{"EndOfYear", EndOfYear(tm, loc), "2024-12-31 23:59:59.999999999"},
+		// UTC时间在上海已是次日
+		{"StartOfDay(UTC input)", StartOfDay(time.Date(2024, 7, 28, 20, 0, 0, 0, TimezoneUtc), loc), "2024-07-29 00:00:00"},
+	}
+	for _, test := range tests {
+		if got := test.got.Format(layout); got != test.expected || test.got.Location() != loc {
+			t.Errorf("%s = %s (%v); want %s", test.name, got, test.got.Location(), test.expected)
+		}
+	}
+}
+
+func TestDayOfDST(t *testing.T) {
+	loc := TimezoneNy
+	tests := []struct {
+		day      time.Time
+		expected time.Duration
+	}{
+		{time.Date(2024, 3, 10, 12, 0, 0, 0, loc), 23 * time.Hour},
+		{time.Date(2024, 11, 3, 12, 0, 0, 0, loc), 25 * time.Hour},
+		{time.Date(2024, 7, 4, 12, 0, 0, 0, loc), 24 * time.Hour},
+	}
+	for _, test := range tests {
+		if got := DayOf(test.day, loc).Duration(); got != test.expected {
+			t.Errorf("DayOf(%v).Duration() = %v; want %v", test.day, got, test.expected)
+		}
+		if got := EndOfDay(test.day, loc).Add(time.Nanosecond); !got.Equal(StartOfDay(test.day.AddDate(0, 0, 1), loc)) {
+			t.Errorf("EndOfDay(%v) + 1ns = %v; want next midnight", test.day, got)
+		}
+	}
+	if iv := MonthOf(time.Date(2024, 3, 15, 0, 0, 0, 0, loc), loc); iv.Duration() != 31*24*time.Hour-time.Hour {
+		t.Errorf("MonthOf(2024-03).Duration() = %v", iv.Duration())
+	}
+	if iv := WeekOf(time.Date(2024, 3, 10, 0, 0, 0, 0, loc), time.Monday, loc); !iv.Contains(time.Date(2024, 3, 10, 23, 59, 0, 0, loc)) || iv.Contains(time.Date(2024, 3, 11, 0, 0, 0, 0, loc)) {
+		t.Errorf("WeekOf() = %v; want half-open week", iv)
+	}
+	if iv := QuarterOf(time.Date(2024, 5, 1, 0, 0, 0, 0, loc), loc); iv.Start.Month() != time.April || iv.End.Month() != time.July {
+		t.Errorf("QuarterOf() = %v", iv)
+	}
+	if iv := YearOf(time.Date(2024, 5, 1, 0, 0, 0, 0, loc), loc); iv.End.Year() != 2025 {
+		t.Errorf("YearOf() = %v", iv)
+	}
+}
+
+func TestDayOfSkippedMidnight(t *testing.T) {
+	// 圣保罗2018-11-04零点时钟拨快到01:00，当天从01:00开始
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	if err != nil {
+		t.Skip("tzdata not available")
+	}
+	start := time.Date(2018, 11, 4, 3, 0, 0, 0, time.UTC) // 01:00 -02
+	if got := StartOfDay(time.Date(2018, 11, 4, 12, 0, 0, 0, loc), loc); !got.Equal(start) {
+		t.Errorf("StartOfDay(2018-11-04) = %v; want %v", got, start)
+	}
+	if iv := DayOf(time.Date(2018, 11, 4, 12, 0, 0, 0, loc), loc); !iv.Start.Equal(start) || iv.Duration() != 23*time.Hour {
+		t.Errorf("DayOf(2018-11-04) = %v; want 23 hours from %v", iv, start)
+	}
+	if iv := DayOf(time.Date(2018, 11, 3, 12, 0, 0, 0, loc), loc); !iv.End.Equal(start) || iv.Duration() != 24*time.Hour {
+		t.Errorf("DayOf(2018-11-03) = %v; want to end at %v", iv, start)
+	}
+	if got := DailyPeriod().Next(time.Date(2018, 11, 3, 12, 0, 0, 0, loc), loc); !got.Equal(start) {
+		t.Errorf("DailyPeriod().Next(2018-11-03) = %v; want %v", got, start)
+	}
+}
+
+func TestStartOfDayUnix(t *testing.T) {
+	tm := time.Date(2024, 7, 28, 10, 15, 30, 0, TimezoneShanghai)
+	if got := StartOfDayUnix(tm, TimezoneShanghai); got != 1722096000 {
+		t.Errorf("StartOfDayUnix() = %d; want 1722096000", got)
+	}
+	if got := EndOfDayUnix(tm, TimezoneShanghai); got != 1722182399 {
+		t.Errorf("EndOfDayUnix() = %d; want 1722182399", got)
+	}
+	if got := StartOfDayUnix(tm, TimezoneUtc); got != 1722124800 {
+		t.Errorf("StartOfDayUnix(UTC) = %d; want 1722124800", got)
+	}
+}
@@ -0,0 +1,122 @@
+package timeutil
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// stepClock 测试用时钟，时间只在Advance时前进；定时器和Sleep不真正等待，创建时将时间前进d并立即触发
+type stepClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newStepClock(start time.Time) *stepClock {
+	return &stepClock{now: start}
+}
+
+func (c *stepClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *stepClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+func (c *stepClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (c *stepClock) NewTimer(d time.Duration) Timer {
+	c.Advance(d)
+	ch := make(chan time.Time, 1)
+	ch <- c.Now()
+	return stepTimer{ch}
+}
+
+func (c *stepClock) NewTicker(d time.Duration) Ticker {
+	return stepTicker{c.NewTimer(d).C()}
+}
+
+func (c *stepClock) AfterFunc(d time.Duration, f func()) Timer {
+	t := c.NewTimer(d)
+	f()
+	return t
+}
+
+func (c *stepClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+func (c *stepClock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
+
+// stepTimer 已触发的定时器
+type stepTimer struct {
+	ch <-chan time.Time
+}
+
+func (t stepTimer) C() <-chan time.Time      { return t.ch }
+func (t stepTimer) Stop() bool               { return false }
+func (t stepTimer) Reset(time.Duration) bool { return false }
+
+// stepTicker 只触发一次的周期定时器
+type stepTicker struct {
+	ch <-chan time.Time
+}
+
+func (t stepTicker) C() <-chan time.Time { return t.ch }
+func (t stepTicker) Stop()               {}
+func (t stepTicker) Reset(time.Duration) {}
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	if now := SystemClock.Now(); now.Before(before) {
+		t.Errorf("SystemClock.Now() = %v; want >= %v", now, before)
+	}
+	timer := SystemClock.NewTimer(time.Millisecond)
+	select {
+	case <-timer.C():
+	case <-time.After(time.Second):
+		t.Errorf("SystemClock timer did not fire")
+	}
+	select {
+	case <-SystemClock.After(time.Millisecond):
+	case <-time.After(time.Second):
+		t.Errorf("SystemClock.After did not fire")
+	}
+	ticker := SystemClock.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	select {
+	case <-ticker.C():
+	case <-time.After(time.Second):
+		t.Errorf("SystemClock ticker did not fire")
+	}
+	if SystemClock.Since(before) <= 0 {
+		t.Errorf("SystemClock.Since(%v) <= 0", before)
+	}
+}
+
+func TestSleepContext(t *testing.T) {
+	clock := newStepClock(fixedTime(getTestTimezone()))
+	start := clock.Now()
+	if err := sleepContext(context.Background(), clock, time.Minute); err != nil {
+		t.Errorf("sleepContext() = %v; want nil", err)
+	}
+	if got := clock.Since(start); got != time.Minute {
+		t.Errorf("sleepContext() slept %v; want %v", got, time.Minute)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := sleepContext(ctx, SystemClock, time.Minute); err != context.Canceled {
+		t.Errorf("sleepContext(canceled) = %v; want context.Canceled", err)
+	}
+}
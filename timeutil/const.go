@@ -25,3 +25,4 @@ var TimezoneUtc = time.UTC
 var TimezoneShanghai, _ = time.LoadLocation("Asia/Shanghai")
 var TimezoneJp, _ = time.LoadLocation("Asia/Tokyo")
 var TimezoneLa, _ = time.LoadLocation("America/Los_Angeles")
+var TimezoneNy, _ = time.LoadLocation("America/New_York")
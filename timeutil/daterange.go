@@ -0,0 +1,49 @@
+package timeutil
+
+import (
+	"time"
+)
+
+// DateRange 按天的日期范围，Start、End为起止日的零点，包括起止日
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewDateRange 创建从start所在日到end所在日的日期范围，start晚于end时自动交换
+func NewDateRange(start, end time.Time, timezone *time.Location) DateRange {
+	s, e := StartOfDay(start, timezone), StartOfDay(end, timezone)
+	if e.Before(s) {
+		s, e = e, s
+	}
+	return DateRange{Start: s, End: e}
+}
+
+// NewDateRangeBiDay 由YYYYMMDD格式的起止日创建日期范围
+func NewDateRangeBiDay(from, to string, timezone *time.Location) DateRange {
+	return NewDateRange(Str2Time(from, FormatYYYYMMDDNoSymbol, timezone), Str2Time(to, FormatYYYYMMDDNoSymbol, timezone), timezone)
+}
+
+// IsSingleDay 是否只包含一天
+func (r DateRange) IsSingleDay() bool {
+	return r.Start.Equal(r.End)
+}
+
+// Days 范围内的所有天，格式为YYYYMMDD
+func (r DateRange) Days() []string {
+	var ret []string
+	for t := r.Start; !t.After(r.End); t = t.AddDate(0, 0, 1) {
+		ret = append(ret, t.Format(FormatYYYYMMDDNoSymbol))
+	}
+	return ret
+}
+
+// Interval 范围对应的时间区间 [Start, End次日零点)
+func (r DateRange) Interval() Interval {
+	return Interval{Start: r.Start, End: r.End.AddDate(0, 0, 1)}
+}
+
+// Contains 判断t是否落在范围内
+func (r DateRange) Contains(t time.Time) bool {
+	return r.Interval().Contains(t)
+}
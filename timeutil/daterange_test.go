@@ -0,0 +1,24 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateRange(t *testing.T) {
+	loc := getTestTimezone()
+	r := NewDateRange(time.Date(2024, 7, 5, 18, 0, 0, 0, loc), time.Date(2024, 7, 1, 9, 0, 0, 0, loc), loc)
+
+	if expected := []string{"20240701", "20240702", "20240703", "20240704", "20240705"}; !equalStringSlices(r.Days(), expected) {
+		t.Errorf("Days() = %v; want %v", r.Days(), expected)
+	}
+	if !r.Contains(time.Date(2024, 7, 5, 23, 59, 59, 0, loc)) || r.Contains(time.Date(2024, 7, 6, 0, 0, 0, 0, loc)) {
+		t.Errorf("Contains() returned unexpected result for %v", r)
+	}
+	if r.IsSingleDay() || !NewDateRangeBiDay("20240701", "20240701", loc).IsSingleDay() {
+		t.Errorf("IsSingleDay() returned unexpected result")
+	}
+	if got := r.Interval().Duration(); got != 5*24*time.Hour {
+		t.Errorf("Interval().Duration() = %v; want %v", got, 5*24*time.Hour)
+	}
+}
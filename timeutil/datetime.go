@@ -107,16 +107,22 @@ func AddYear(t time.Time, year int64) time.Time {
 }
 
 // GetTodayStartTime 今天的开始时间, yyyy-mm-dd 00:00:00.
+//
+// Deprecated: 只能获取本机时区的今天，请使用StartOfDay(t, timezone).Format(FormatYYYYMMDDHHMMSS)
 func GetTodayStartTime() string {
 	return time.Now().Format("2006-01-02") + " 00:00:00"
 }
 
 // GetTodayEndTime 今天的结束时间, format: yyyy-mm-dd 23:59:59.
+//
+// Deprecated: 只能获取本机时区的今天且不含亚秒，请使用EndOfDay，或用DayOf得到左闭右开的区间
 func GetTodayEndTime() string {
 	return time.Now().Format("2006-01-02") + " 23:59:59"
 }
 
 // GetZeroHourTimestamp 今天的零点秒级时间戳 (timestamp of 00:00).
+//
+// Deprecated: 返回的是UTC零点而不是tz的零点，为兼容保留原有行为，请使用StartOfDayUnix(time.Now(), tz)
 func GetZeroHourTimestamp(tz *time.Location) int64 {
 	ts := time.Now().Format("2006-01-02")
 	t, _ := time.Parse("2006-01-02", ts)
@@ -124,6 +130,8 @@ func GetZeroHourTimestamp(tz *time.Location) int64 {
 }
 
 // GetNightTimestamp 今天的最后一秒的秒级时间戳 (timestamp of 23:59).
+//
+// Deprecated: 与GetZeroHourTimestamp一样按UTC计算，请使用EndOfDayUnix(time.Now(), tz)
 func GetNightTimestamp(tz *time.Location) int64 {
 	return GetZeroHourTimestamp(tz) + 86400 - 1
 }
@@ -150,6 +158,8 @@ func DateSerialFormat(dateSerial int, format string, timezone *time.Location) st
 }
 
 // TraceFuncTime 跟踪func计算时间，只需在func顶部将其称为“defer TraceFuncTime()()”
+//
+// Deprecated: 耗时直接打印到标准输出且无法测试，请使用Stopwatch或Profiler.Trace
 func TraceFuncTime() func() {
 	pre := time.Now()
 	return func() {
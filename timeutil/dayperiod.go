@@ -0,0 +1,100 @@
+package timeutil
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DayPeriod 一天中的时段 [Start, End)，Start、End为当地墙上时间距零点的偏移，End最大为24小时。
+// 夏令时切换日按墙上时间计算，例如09:30-16:00总是当地时间的09:30至16:00
+type DayPeriod struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseDayPeriod 解析"HH:MM-HH:MM"格式的时段，也支持"HH:MM:SS"，结束时间可以为24:00，例如"09:30-11:30"
+func ParseDayPeriod(s string) (DayPeriod, error) {
+	from, to, ok := strings.Cut(strings.TrimSpace(s), "-")
+	if !ok {
+		return DayPeriod{}, fmt.Errorf("timeutil: invalid day period %q", s)
+	}
+	start, err1 := parseClockTime(from)
+	end, err2 := parseClockTime(to)
+	if err1 != nil || err2 != nil || start >= end || end > 24*time.Hour {
+		return DayPeriod{}, fmt.Errorf("timeutil: invalid day period %q", s)
+	}
+	return DayPeriod{Start: start, End: end}, nil
+}
+
+// ParseDayPeriods 解析逗号分隔的多个时段，例如"09:30-11:30,13:00-15:00"
+func ParseDayPeriods(s string) ([]DayPeriod, error) {
+	var periods []DayPeriod
+	for _, part := range strings.Split(s, ",") {
+		p, err := ParseDayPeriod(part)
+		if err != nil {
+			return nil, err
+		}
+		periods = append(periods, p)
+	}
+	return periods, nil
+}
+
+// MustParseDayPeriods 同ParseDayPeriods，解析失败时panic，用于声明常量时段
+func MustParseDayPeriods(s string) []DayPeriod {
+	periods, err := ParseDayPeriods(s)
+	if err != nil {
+		panic(err)
+	}
+	return periods
+}
+
+// On t在timezone下所在日的该时段
+func (p DayPeriod) On(t time.Time, timezone *time.Location) Interval {
+	y, m, d := t.In(timezone).Date()
+	return Interval{
+		Start: time.Date(y, m, d, 0, 0, 0, int(p.Start), timezone),
+		End:   time.Date(y, m, d, 0, 0, 0, int(p.End), timezone),
+	}
+}
+
+// Contains t在timezone下的墙上时间是否落在时段内
+func (p DayPeriod) Contains(t time.Time, timezone *time.Location) bool {
+	return p.On(t, timezone).Contains(t)
+}
+
+// String "HH:MM-HH:MM"格式的时段，有秒时为"HH:MM:SS"
+func (p DayPeriod) String() string {
+	return formatClockTime(p.Start) + "-" + formatClockTime(p.End)
+}
+
+// parseClockTime 解析"HH:MM"或"HH:MM:SS"为距零点的偏移，允许24:00
+func parseClockTime(s string) (time.Duration, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("timeutil: invalid clock time %q", s)
+	}
+	limits := []int{24, 59, 59}
+	units := []time.Duration{time.Hour, time.Minute, time.Second}
+	var d time.Duration
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || len(part) != 2 || n < 0 || n > limits[i] {
+			return 0, fmt.Errorf("timeutil: invalid clock time %q", s)
+		}
+		d += time.Duration(n) * units[i]
+	}
+	if d > 24*time.Hour {
+		return 0, fmt.Errorf("timeutil: invalid clock time %q", s)
+	}
+	return d, nil
+}
+
+func formatClockTime(d time.Duration) string {
+	h, m, s := int(d/time.Hour), int(d%time.Hour/time.Minute), int(d%time.Minute/time.Second)
+	if s != 0 {
+		return fmt.Sprintf("%02d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", h, m)
+}
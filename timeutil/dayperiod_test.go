@@ -0,0 +1,46 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDayPeriod(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"09:30-11:30", "09:30-11:30", true},
+		{" 13:00 - 15:00 ", "13:00-15:00", true},
+		{"22:00-24:00", "22:00-24:00", true},
+		{"09:30:15-10:00", "09:30:15-10:00", true},
+		{"11:30-09:30", "", false},
+		{"9:30-11:30", "", false},
+		{"09:60-11:30", "", false},
+		{"09:30-24:01", "", false},
+		{"09:30", "", false},
+	}
+	for _, test := range tests {
+		p, err := ParseDayPeriod(test.input)
+		if (err == nil) != test.ok || (test.ok && p.String() != test.expected) {
+			t.Errorf("ParseDayPeriod(%q) = %v, %v; want %s", test.input, p, err, test.expected)
+		}
+	}
+	if _, err := ParseDayPeriods("09:30-11:30,bad"); err == nil {
+		t.Errorf("ParseDayPeriods() error = nil; want error")
+	}
+}
+
+func TestDayPeriodOn(t *testing.T) {
+	p := MustParseDayPeriods("01:00-04:00")[0]
+	// 2024-03-10 美国夏令时开始，02:00跳到03:00，墙上时间01:00-04:00只有2小时
+	iv := p.On(time.Date(2024, 3, 10, 12, 0, 0, 0, TimezoneNy), TimezoneNy)
+	if iv.Duration() != 2*time.Hour || iv.Start.In(TimezoneNy).Hour() != 1 || iv.End.In(TimezoneNy).Hour() != 4 {
+		t.Errorf("On(2024-03-10) = %v", iv)
+	}
+	loc := getTestTimezone()
+	if !p.Contains(time.Date(2024, 7, 28, 1, 0, 0, 0, loc), loc) || p.Contains(time.Date(2024, 7, 28, 4, 0, 0, 0, loc), loc) {
+		t.Errorf("Contains() should be half-open")
+	}
+}
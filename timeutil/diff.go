@@ -0,0 +1,134 @@
+package timeutil
+
+import (
+	"math"
+	"time"
+)
+
+// DiffUnit 时间差的单位
+type DiffUnit int
+
+const (
+	DiffYear DiffUnit = iota
+	DiffQuarter
+	DiffMonth
+	DiffWeek
+	DiffDay
+	DiffHour
+)
+
+// DiffMode 时间差的取整方式
+type DiffMode int
+
+const (
+	DiffFloor DiffMode = iota // 只计完整的单位数，按绝对值向下取整
+	DiffRound                 // 四舍五入
+	DiffExact                 // 带小数的精确值
+)
+
+// Diff 计算t1-t2相差的单位数，年、季、月、周、日在timezone下按自然日历计算，不受夏令时影响；小时按实际流逝时间计算。
+// 不满一个单位的部分按所在单位的实际长度折算小数，例如2024年1月31日到2月15日为15/29个月(1月31日至2月29日共29天)；mode默认DiffFloor
+func Diff(t1, t2 time.Time, unit DiffUnit, timezone *time.Location, mode ...DiffMode) float64 {
+	m := DiffFloor
+	if len(mode) > 0 {
+		m = mode[0]
+	}
+	if t1.Before(t2) {
+		return -Diff(t2, t1, unit, timezone, m)
+	}
+
+	var whole int
+	var frac float64
+	if unit == DiffHour {
+		hours := t1.Sub(t2).Hours()
+		whole, frac = int(hours), hours-math.Trunc(hours)
+	} else {
+		whole, frac = civilDiff(t1.In(timezone), t2.In(timezone), unit)
+	}
+
+	switch m {
+	case DiffRound:
+		return math.Round(float64(whole) + frac)
+	case DiffExact:
+		return float64(whole) + frac
+	}
+	return float64(whole)
+}
+
+// DiffByDay 计算两个日期字符串day1-day2相差的单位数，format为时间格式，为空时默认YYYYMMDD；mode与Diff相同，默认DiffFloor
+func DiffByDay(day1, day2 string, unit DiffUnit, timezone *time.Location, format string, mode ...DiffMode) float64 {
+	if format == "" {
+		format = FormatYYYYMMDDNoSymbol
+	}
+	return Diff(Str2Time(day1, format, timezone), Str2Time(day2, format, timezone), unit, timezone, mode...)
+}
+
+// YearDiff 两个时间相差的自然年数
+func YearDiff(t1, t2 time.Time, timezone *time.Location, mode ...DiffMode) float64 {
+	return Diff(t1, t2, DiffYear, timezone, mode...)
+}
+
+// QuarterDiff 两个时间相差的季度数
+func QuarterDiff(t1, t2 time.Time, timezone *time.Location, mode ...DiffMode) float64 {
+	return Diff(t1, t2, DiffQuarter, timezone, mode...)
+}
+
+// MonthDiff 两个时间相差的自然月数
+func MonthDiff(t1, t2 time.Time, timezone *time.Location, mode ...DiffMode) float64 {
+	return Diff(t1, t2, DiffMonth, timezone, mode...)
+}
+
+// WeekDiff 两个时间相差的周数
+func WeekDiff(t1, t2 time.Time, timezone *time.Location, mode ...DiffMode) float64 {
+	return Diff(t1, t2, DiffWeek, timezone, mode...)
+}
+
+// DayDiffTime 两个时间相差的自然日数，夏令时切换日也按一天计算
+func DayDiffTime(t1, t2 time.Time, timezone *time.Location, mode ...DiffMode) float64 {
+	return Diff(t1, t2, DiffDay, timezone, mode...)
+}
+
+// HourDiff 两个时间相差的小时数
+func HourDiff(t1, t2 time.Time, mode ...DiffMode) float64 {
+	return Diff(t1, t2, DiffHour, TimezoneUtc, mode...)
+}
+
+// civilDiff 按自然日历计算later-earlier的完整单位数及剩余小数部分，两者需在同一时区
+func civilDiff(later, earlier time.Time, unit DiffUnit) (int, float64) {
+	step, monthly := 1, true
+	switch unit {
+	case DiffYear:
+		step = 12
+	case DiffQuarter:
+		step = 3
+	case DiffWeek:
+		step, monthly = 7, false
+	case DiffDay:
+		monthly = false
+	}
+	add := func(k int) time.Time {
+		y, mo, d := earlier.Date()
+		h, mi, s := earlier.Clock()
+		if monthly {
+			y, mo, d = addCivilMonths(y, mo, d, k*step, LeapDayFeb28)
+		} else {
+			d += k * step
+		}
+		return time.Date(y, mo, d, h, mi, s, earlier.Nanosecond(), earlier.Location())
+	}
+
+	var k int
+	if monthly {
+		k = ((later.Year()-earlier.Year())*12 + int(later.Month()-earlier.Month())) / step
+	} else {
+		k = (civilDays(later.Date()) - civilDays(earlier.Date())) / step
+	}
+	for k > 0 && add(k).After(later) {
+		k--
+	}
+	for !add(k + 1).After(later) {
+		k++
+	}
+	from, to := add(k), add(k+1)
+	return k, float64(later.Sub(from)) / float64(to.Sub(from))
+}
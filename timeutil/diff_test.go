@@ -0,0 +1,84 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiff(t *testing.T) {
+	loc := getTestTimezone()
+	la := TimezoneLa
+	tests := []struct {
+		name     string
+		t1       time.Time
+		t2       time.Time
+		unit     DiffUnit
+		timezone *time.Location
+		mode     DiffMode
+		expected float64
+	}{
+		{"year floor", time.Date(2024, 7, 27, 0, 0, 0, 0, loc), time.Date(2021, 7, 28, 0, 0, 0, 0, loc), DiffYear, loc, DiffFloor, 2},
+		{"year round", time.Date(2024, 7, 27, 0, 0, 0, 0, loc), time.Date(2021, 7, 28, 0, 0, 0, 0, loc), DiffYear, loc, DiffRound, 3},
+		{"quarter", time.Date(2024, 7, 1, 0, 0, 0, 0, loc), time.Date(2024, 1, 1, 0, 0, 0, 0, loc), DiffQuarter, loc, DiffFloor, 2},
+		{"month exact", time.Date(2024, 2, 15, 0, 0, 0, 0, loc), time.Date(2024, 1, 31, 0, 0, 0, 0, loc), DiffMonth, loc, DiffExact, 15.0 / 29},
+		{"month end", time.Date(2024, 2, 29, 0, 0, 0, 0, loc), time.Date(2024, 1, 31, 0, 0, 0, 0, loc), DiffMonth, loc, DiffFloor, 1},
+		{"negative month", time.Date(2024, 1, 1, 0, 0, 0, 0, loc), time.Date(2024, 3, 15, 0, 0, 0, 0, loc), DiffMonth, loc, DiffFloor, -2},
+		{"week", time.Date(2024, 7, 28, 0, 0, 0, 0, loc), time.Date(2024, 7, 1, 0, 0, 0, 0, loc), DiffWeek, loc, DiffExact, 27.0 / 7},
+		{"day across dst", time.Date(2024, 3, 11, 0, 0, 0, 0, la), time.Date(2024, 3, 10, 0, 0, 0, 0, la), DiffDay, la, DiffExact, 1},
+		{"half day on dst day", time.Date(2024, 3, 10, 12, 30, 0, 0, la), time.Date(2024, 3, 10, 0, 0, 0, 0, la), DiffDay, la, DiffExact, 0.5},
+		{"hour across dst", time.Date(2024, 3, 11, 0, 0, 0, 0, la), time.Date(2024, 3, 10, 0, 0, 0, 0, la), DiffHour, la, DiffExact, 23},
+		{"day in other timezone", time.Date(2024, 7, 2, 1, 0, 0, 0, loc), time.Date(2024, 7, 1, 23, 0, 0, 0, loc), DiffDay, TimezoneUtc, DiffFloor, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Diff(tt.t1, tt.t2, tt.unit, tt.timezone, tt.mode); got != tt.expected {
+				t.Errorf("Diff() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDiffByDay(t *testing.T) {
+	tests := []struct {
+		day1     string
+		day2     string
+		unit     DiffUnit
+		format   string
+		mode     []DiffMode
+		expected float64
+	}{
+		{"20240728", "20240701", DiffDay, FormatYYYYMMDDNoSymbol, nil, 27},
+		{"2024-07-28", "2024-01-28", DiffMonth, FormatYYYYMMDD, nil, 6},
+		{"20240101", "20240728", DiffWeek, "", nil, -29},
+		{"20240215", "20240131", DiffMonth, "", []DiffMode{DiffExact}, 15.0 / 29},
+	}
+	for _, test := range tests {
+		if result := DiffByDay(test.day1, test.day2, test.unit, TimezoneShanghai, test.format, test.mode...); result != test.expected {
+			t.Errorf("DiffByDay(%q, %q, %v) = %v; want %v", test.day1, test.day2, test.unit, result, test.expected)
+		}
+	}
+}
+
+func TestDiffShortcuts(t *testing.T) {
+	loc := getTestTimezone()
+	t1 := time.Date(2025, 10, 1, 6, 0, 0, 0, loc)
+	t2 := time.Date(2024, 7, 28, 0, 0, 0, 0, loc)
+	if got := YearDiff(t1, t2, loc); got != 1 {
+		t.Errorf("YearDiff() = %v, want 1", got)
+	}
+	if got := QuarterDiff(t1, t2, loc); got != 4 {
+		t.Errorf("QuarterDiff() = %v, want 4", got)
+	}
+	if got := MonthDiff(t1, t2, loc); got != 14 {
+		t.Errorf("MonthDiff() = %v, want 14", got)
+	}
+	if got := WeekDiff(t1, t2, loc); got != 61 {
+		t.Errorf("WeekDiff() = %v, want 61", got)
+	}
+	if got := DayDiffTime(t1, t2, loc, DiffExact); got != 430.25 {
+		t.Errorf("DayDiffTime() = %v, want 430.25", got)
+	}
+	if got := HourDiff(t1, t2); got != 430*24+6 {
+		t.Errorf("HourDiff() = %v, want %v", got, 430*24+6)
+	}
+}
@@ -0,0 +1,251 @@
+package timeutil
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FakeClock 测试用时钟，时间只在调用Advance时前进。
+// 由它创建的Timer、Ticker、AfterFunc、After、Sleep只在虚拟时间到达时触发，AfterFunc的f在Advance所在的goroutine中同步执行
+type FakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*fakeWaiter
+}
+
+// fakeWaiter FakeClock上等待触发的定时器
+type fakeWaiter struct {
+	clock  *FakeClock
+	when   time.Time
+	period time.Duration // 大于0时为Ticker
+	ch     chan time.Time
+	fn     func()
+	active bool
+}
+
+// NewFakeClock 创建从start开始的测试时钟
+func NewFakeClock(start time.Time) *FakeClock {
+	c := &FakeClock{now: start}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now 当前虚拟时间
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Since 距t的虚拟时长
+func (c *FakeClock) Since(t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// NewTimer 创建在虚拟时间d之后触发的定时器
+func (c *FakeClock) NewTimer(d time.Duration) Timer {
+	return c.addWaiter(&fakeWaiter{ch: make(chan time.Time, 1)}, d)
+}
+
+// NewTicker 创建每隔虚拟时间d触发一次的周期定时器
+func (c *FakeClock) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("timeutil: non-positive interval for FakeClock.NewTicker")
+	}
+	return fakeTicker{c.addWaiter(&fakeWaiter{ch: make(chan time.Time, 1), period: d}, d)}
+}
+
+// AfterFunc 在虚拟时间d之后调用f
+func (c *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	return c.addWaiter(&fakeWaiter{fn: f}, d)
+}
+
+// After 等同于NewTimer(d).C()
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	return c.NewTimer(d).C()
+}
+
+// Sleep 阻塞直到虚拟时间前进d
+func (c *FakeClock) Sleep(d time.Duration) {
+	<-c.After(d)
+}
+
+// Advance 将虚拟时间前进d，并按到期时间顺序触发期间到期的定时器
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	target := c.now.Add(d)
+	c.mu.Unlock()
+	c.AdvanceTo(target)
+}
+
+// AdvanceTo 将虚拟时间前进到t，t早于当前虚拟时间时不做任何事
+func (c *FakeClock) AdvanceTo(t time.Time) {
+	for {
+		c.mu.Lock()
+		w := c.earliest()
+		if w == nil || w.when.After(t) {
+			if t.After(c.now) {
+				c.now = t
+			}
+			c.mu.Unlock()
+			return
+		}
+		if w.when.After(c.now) {
+			c.now = w.when
+		}
+		fn := w.fire(c.now)
+		c.mu.Unlock()
+		if fn != nil {
+			fn()
+		}
+	}
+}
+
+// AdvanceToNext 将虚拟时间前进到最早的定时器到期时间并触发它，没有等待中的定时器时返回false
+func (c *FakeClock) AdvanceToNext() bool {
+	c.mu.Lock()
+	w := c.earliest()
+	c.mu.Unlock()
+	if w == nil {
+		return false
+	}
+	c.AdvanceTo(w.when)
+	return true
+}
+
+// Waiters 等待触发的定时器数量，包括Timer、Ticker、AfterFunc及阻塞在After、Sleep上的goroutine
+func (c *FakeClock) Waiters() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.activeWaiters()
+}
+
+// BlockUntil 阻塞直到至少有n个等待触发的定时器，用于确保被测goroutine已开始等待后再调用Advance
+func (c *FakeClock) BlockUntil(n int) {
+	_ = c.BlockUntilContext(context.Background(), n)
+}
+
+// BlockUntilContext 同BlockUntil，ctx结束时返回ctx.Err()
+func (c *FakeClock) BlockUntilContext(ctx context.Context, n int) error {
+	stop := context.AfterFunc(ctx, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.cond.Broadcast()
+	})
+	defer stop()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.activeWaiters() < n {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		c.cond.Wait()
+	}
+	return nil
+}
+
+// addWaiter 注册定时器，调用方不能持有锁
+func (c *FakeClock) addWaiter(w *fakeWaiter, d time.Duration) *fakeWaiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	w.clock = c
+	w.when = c.now.Add(d)
+	w.active = true
+	active := c.waiters[:0]
+	for _, existing := range c.waiters {
+		if existing.active {
+			active = append(active, existing)
+		}
+	}
+	c.waiters = append(active, w)
+	c.cond.Broadcast()
+	return w
+}
+
+// earliest 最早到期的定时器，调用方需持有锁
+func (c *FakeClock) earliest() *fakeWaiter {
+	var ret *fakeWaiter
+	for _, w := range c.waiters {
+		if w.active && (ret == nil || w.when.Before(ret.when)) {
+			ret = w
+		}
+	}
+	return ret
+}
+
+// activeWaiters 等待触发的定时器数量，调用方需持有锁
+func (c *FakeClock) activeWaiters() int {
+	n := 0
+	for _, w := range c.waiters {
+		if w.active {
+			n++
+		}
+	}
+	return n
+}
+
+// fire 触发定时器，调用方需持有锁；返回需要在释放锁后执行的AfterFunc回调
+func (w *fakeWaiter) fire(now time.Time) func() {
+	if w.period > 0 {
+		w.when = w.when.Add(w.period)
+	} else {
+		w.active = false
+	}
+	if w.fn != nil {
+		return w.fn
+	}
+	// 与time.Ticker一致，接收方未及时读取时丢弃本次触发
+	select {
+	case w.ch <- now:
+	default:
+	}
+	return nil
+}
+
+// C 触发时接收虚拟时间的通道，AfterFunc创建的定时器为nil
+func (w *fakeWaiter) C() <-chan time.Time {
+	return w.ch
+}
+
+// Stop 停止定时器，返回停止前是否处于等待状态
+func (w *fakeWaiter) Stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+	active := w.active
+	w.active = false
+	return active
+}
+
+// Reset 重新设置为虚拟时间d之后触发，返回重置前是否处于等待状态
+func (w *fakeWaiter) Reset(d time.Duration) bool {
+	w.clock.mu.Lock()
+	active := w.active
+	w.active = false
+	if w.period > 0 {
+		w.period = d
+	}
+	w.clock.mu.Unlock()
+	w.clock.addWaiter(w, d)
+	return active
+}
+
+// fakeTicker 基于fakeWaiter实现Ticker
+type fakeTicker struct {
+	*fakeWaiter
+}
+
+// Stop 停止周期定时器
+func (t fakeTicker) Stop() {
+	t.fakeWaiter.Stop()
+}
+
+// Reset 停止周期定时器并将周期重置为d
+func (t fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("timeutil: non-positive interval for FakeClock ticker Reset")
+	}
+	t.fakeWaiter.Reset(d)
+}
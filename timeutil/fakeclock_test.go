@@ -0,0 +1,137 @@
+package timeutil
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFakeClockTimer(t *testing.T) {
+	start := fixedTime(getTestTimezone())
+	clock := NewFakeClock(start)
+	timer := clock.NewTimer(time.Minute)
+
+	clock.Advance(59 * time.Second)
+	select {
+	case <-timer.C():
+		t.Fatalf("timer fired before its deadline")
+	default:
+	}
+	clock.Advance(time.Second)
+	select {
+	case got := <-timer.C():
+		if !got.Equal(start.Add(time.Minute)) {
+			t.Errorf("timer fired at %v; want %v", got, start.Add(time.Minute))
+		}
+	default:
+		t.Fatalf("timer did not fire at its deadline")
+	}
+
+	if timer.Reset(time.Second) {
+		t.Errorf("Reset() on fired timer = true; want false")
+	}
+	if !timer.Stop() {
+		t.Errorf("Stop() on pending timer = false; want true")
+	}
+	clock.Advance(time.Hour)
+	select {
+	case <-timer.C():
+		t.Errorf("stopped timer fired")
+	default:
+	}
+}
+
+func TestFakeClockTicker(t *testing.T) {
+	clock := NewFakeClock(fixedTime(getTestTimezone()))
+	ticker := clock.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+
+	count := 0
+	for i := 0; i < 3; i++ {
+		clock.Advance(10 * time.Second)
+		select {
+		case <-ticker.C():
+			count++
+		default:
+		}
+	}
+	if count != 3 {
+		t.Errorf("ticker fired %d times; want 3", count)
+	}
+
+	// 与time.Ticker一致，未读取的触发被丢弃
+	clock.Advance(time.Minute)
+	<-ticker.C()
+	select {
+	case <-ticker.C():
+		t.Errorf("ticker buffered more than one tick")
+	default:
+	}
+
+	ticker.Reset(time.Minute)
+	clock.Advance(30 * time.Second)
+	select {
+	case <-ticker.C():
+		t.Errorf("ticker fired before reset interval")
+	default:
+	}
+}
+
+func TestFakeClockAfterFunc(t *testing.T) {
+	start := fixedTime(getTestTimezone())
+	clock := NewFakeClock(start)
+	var fired []time.Time
+	clock.AfterFunc(2*time.Second, func() { fired = append(fired, clock.Now()) })
+	clock.AfterFunc(time.Second, func() { fired = append(fired, clock.Now()) })
+	stopped := clock.AfterFunc(time.Second, func() { t.Errorf("stopped AfterFunc was called") })
+	stopped.Stop()
+
+	clock.Advance(5 * time.Second)
+	if len(fired) != 2 || !fired[0].Equal(start.Add(time.Second)) || !fired[1].Equal(start.Add(2*time.Second)) {
+		t.Errorf("AfterFunc fired at %v; want in deadline order", fired)
+	}
+	if !clock.Now().Equal(start.Add(5 * time.Second)) {
+		t.Errorf("Now() = %v; want %v", clock.Now(), start.Add(5*time.Second))
+	}
+}
+
+func TestFakeClockSleep(t *testing.T) {
+	start := fixedTime(getTestTimezone())
+	clock := NewFakeClock(start)
+	done := make(chan time.Time, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			clock.Sleep(time.Minute)
+			done <- clock.Now()
+		}()
+	}
+	clock.BlockUntil(2)
+	if got := clock.Waiters(); got != 2 {
+		t.Errorf("Waiters() = %d; want 2", got)
+	}
+	if !clock.AdvanceToNext() {
+		t.Fatalf("AdvanceToNext() = false; want true")
+	}
+	clock.AdvanceToNext()
+	for i := 0; i < 2; i++ {
+		if got := <-done; !got.Equal(start.Add(time.Minute)) {
+			t.Errorf("Sleep returned at %v; want %v", got, start.Add(time.Minute))
+		}
+	}
+	if clock.AdvanceToNext() {
+		t.Errorf("AdvanceToNext() without waiters = true; want false")
+	}
+}
+
+func TestFakeClockBlockUntilContext(t *testing.T) {
+	clock := NewFakeClock(fixedTime(getTestTimezone()))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := clock.BlockUntilContext(ctx, 1); err != context.Canceled {
+		t.Errorf("BlockUntilContext(canceled) = %v; want context.Canceled", err)
+	}
+	clock.After(time.Second)
+	if err := clock.BlockUntilContext(context.Background(), 1); err != nil {
+		t.Errorf("BlockUntilContext() = %v; want nil", err)
+	}
+}
@@ -0,0 +1,339 @@
+package timeutil
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// HolidayKind 节假日安排的类型
+type HolidayKind string
+
+const (
+	HolidayOff     HolidayKind = "holiday" // 放假
+	HolidayWorkday HolidayKind = "workday" // 调休上班
+)
+
+// Holiday 某一天的节假日安排
+type Holiday struct {
+	Date time.Time   // 日期，UTC零点表示的自然日
+	Name string      // 节日名称
+	Kind HolidayKind // 放假或调休上班
+}
+
+// Day YYYYMMDD格式的日期
+func (h Holiday) Day() string {
+	return h.Date.Format(FormatYYYYMMDDNoSymbol)
+}
+
+//go:embed holidays_cn.txt
+var chinaHolidayData string
+
+// ParseHolidayData 解析节假日数据，每行格式为"日期或日期范围 类型 名称"，例如：
+//
+//	2024-02-10~2024-02-17 holiday 春节
+//	2024-02-04 workday 春节
+//
+// 日期为YYYY-MM-DD，范围包括起止日，类型为holiday或workday；空行和#开头的注释行忽略
+func ParseHolidayData(r io.Reader) ([]Holiday, error) {
+	var holidays []Holiday
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			return nil, fmt.Errorf("timeutil: holiday data line %d: expected \"date kind name\", got %q", lineNo, line)
+		}
+		kind := HolidayKind(fields[1])
+		if kind != HolidayOff && kind != HolidayWorkday {
+			return nil, fmt.Errorf("timeutil: holiday data line %d: unknown kind %q", lineNo, fields[1])
+		}
+		from, to, _ := strings.Cut(fields[0], "~")
+		if to == "" {
+			to = from
+		}
+		start, err1 := time.Parse(FormatYYYYMMDD, from)
+		end, err2 := time.Parse(FormatYYYYMMDD, to)
+		if err1 != nil || err2 != nil || end.Before(start) {
+			return nil, fmt.Errorf("timeutil: holiday data line %d: bad date %q", lineNo, fields[0])
+		}
+		name := strings.Join(fields[2:], " ")
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			holidays = append(holidays, Holiday{Date: d, Name: name, Kind: kind})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return holidays, nil
+}
+
+// Calendar 工作日历，周一至周五为工作日，在此基础上按节假日安排放假或调休上班。Calendar创建后不可修改，并发安全。
+// 未收录节假日安排的年份(见Years、Covers)只按周一至周五判断工作日，调用方应先用Covers确认结果可靠
+type Calendar struct {
+	name  string
+	days  map[int]Holiday // key为civilDays
+	years map[int]bool    // 收录了节假日安排的年份
+}
+
+// NewCalendar 由节假日安排创建工作日历，同一天有多条安排时以最后一条为准；安排中出现的年份视为已收录
+func NewCalendar(name string, holidays []Holiday) *Calendar {
+	c := &Calendar{name: name, days: make(map[int]Holiday, len(holidays)), years: make(map[int]bool)}
+	for _, h := range holidays {
+		c.days[holidayKey(h.Date)] = h
+		c.years[h.Date.Year()] = true
+	}
+	return c
+}
+
+// chinaCalendarFromYear 内置中国节假日安排的起始年份，2018年末的元旦假期属于2019年的安排
+const chinaCalendarFromYear = 2019
+
+var chinaCalendar = sync.OnceValue(func() *Calendar {
+	holidays, err := ParseHolidayData(strings.NewReader(chinaHolidayData))
+	if err != nil {
+		panic(err)
+	}
+	c := NewCalendar("China", holidays)
+	for year := range c.years {
+		if year < chinaCalendarFromYear {
+			delete(c.years, year)
+		}
+	}
+	return c
+})
+
+// ChinaCalendar 中国工作日历，内置2019年至2026年国务院办公厅发布的放假及调休安排，可离线使用。
+// 未收录的年份Covers返回false，此时按周一至周五为工作日处理，可通过Overlay叠加ParseHolidayData解析的新安排
+func ChinaCalendar() *Calendar {
+	return chinaCalendar()
+}
+
+// Name 日历名称
+func (c *Calendar) Name() string {
+	return c.name
+}
+
+// Overlay 叠加其他日历的节假日安排，返回新的日历，同一天以后面日历的安排为准，收录的年份为各日历的并集。
+// 例如ChinaCalendar().Overlay(JapanCalendar(2024, 2025))得到中日两地任一放假即休息的日历(中国调休上班日与日本节日重合时休息)
+func (c *Calendar) Overlay(others ...*Calendar) *Calendar {
+	names := []string{c.name}
+	ret := &Calendar{days: make(map[int]Holiday, len(c.days))}
+	for k, h := range c.days {
+		ret.days[k] = h
+	}
+	for _, other := range others {
+		names = append(names, other.name)
+		for k, h := range other.days {
+			ret.days[k] = h
+		}
+	}
+	ret.name = strings.Join(names, "+")
+	ret.years = make(map[int]bool, len(c.years))
+	for _, cal := range append([]*Calendar{c}, others...) {
+		for year := range cal.years {
+			ret.years[year] = true
+		}
+	}
+	return ret
+}
+
+// Years 收录了节假日安排的年份，按升序排列
+func (c *Calendar) Years() []int {
+	ret := make([]int, 0, len(c.years))
+	for year := range c.years {
+		ret = append(ret, year)
+	}
+	sort.Ints(ret)
+	return ret
+}
+
+// Covers 是否收录了year年的节假日安排，未收录的年份IsWorkday等只按周一至周五判断
+func (c *Calendar) Covers(year int) bool {
+	return c.years[year]
+}
+
+// Holidays 某一年的所有节假日安排，按日期排序
+func (c *Calendar) Holidays(year int) []Holiday {
+	var ret []Holiday
+	for _, h := range c.days {
+		if h.Date.Year() == year {
+			ret = append(ret, h)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Date.Before(ret[j].Date) })
+	return ret
+}
+
+// lookup t在timezone下所在日的节假日安排
+func (c *Calendar) lookup(t time.Time, timezone *time.Location) (Holiday, bool) {
+	h, ok := c.days[holidayKey(t.In(timezone))]
+	return h, ok
+}
+
+// IsWorkday t在timezone下所在日是否为工作日
+func (c *Calendar) IsWorkday(t time.Time, timezone *time.Location) bool {
+	if h, ok := c.lookup(t, timezone); ok {
+		return h.Kind == HolidayWorkday
+	}
+	return !IsWeekend(t, timezone)
+}
+
+// IsHoliday t在timezone下所在日是否为节假日放假，不含普通周末
+func (c *Calendar) IsHoliday(t time.Time, timezone *time.Location) bool {
+	h, ok := c.lookup(t, timezone)
+	return ok && h.Kind == HolidayOff
+}
+
+// HolidayName t在timezone下所在日的节假日名称，放假和调休上班日均返回
+func (c *Calendar) HolidayName(t time.Time, timezone *time.Location) (string, bool) {
+	h, ok := c.lookup(t, timezone)
+	return h.Name, ok
+}
+
+// NextWorkday t之后(不含当天)的第一个工作日的零点
+func (c *Calendar) NextWorkday(t time.Time, timezone *time.Location) time.Time {
+	return c.AddWorkdays(t, 1, timezone)
+}
+
+// PreviousWorkday t之前(不含当天)的最近一个工作日的零点
+func (c *Calendar) PreviousWorkday(t time.Time, timezone *time.Location) time.Time {
+	return c.AddWorkdays(t, -1, timezone)
+}
+
+// AddWorkdays t所在日加减n个工作日，返回当日零点；n为0时返回t所在日零点
+func (c *Calendar) AddWorkdays(t time.Time, n int, timezone *time.Location) time.Time {
+	d := StartOfDay(t, timezone)
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		d = d.AddDate(0, 0, step)
+		if c.IsWorkday(d, timezone) {
+			n--
+		}
+	}
+	return d
+}
+
+// CountWorkdays 日期范围内(包括起止日)的工作日数
+func (c *Calendar) CountWorkdays(r DateRange, timezone *time.Location) int {
+	n := 0
+	for d := r.Start; !d.After(r.End); d = d.AddDate(0, 0, 1) {
+		if c.IsWorkday(d, timezone) {
+			n++
+		}
+	}
+	return n
+}
+
+// IsWorkdayBiDay YYYYMMDD格式的day是否为工作日
+func (c *Calendar) IsWorkdayBiDay(day string, timezone *time.Location) bool {
+	return c.IsWorkday(Str2Time(day, FormatYYYYMMDDNoSymbol, timezone), timezone)
+}
+
+// AddWorkdaysBiDay YYYYMMDD格式的day加减n个工作日，格式YYYYMMDD
+func (c *Calendar) AddWorkdaysBiDay(day string, n int, timezone *time.Location) string {
+	t := Str2Time(day, FormatYYYYMMDDNoSymbol, timezone)
+	return c.AddWorkdays(t, n, timezone).Format(FormatYYYYMMDDNoSymbol)
+}
+
+// holidayKey 自然日对应的key
+func holidayKey(t time.Time) int {
+	y, m, d := t.Date()
+	return civilDays(y, m, d)
+}
+
+// JapanCalendar 日本国民祝日日历，按JapanHolidayRules计算fromYear至toYear的祝日，2019年之前的年份不收录
+func JapanCalendar(fromYear, toYear int) *Calendar {
+	return JapanHolidayRules().Calendar(fromYear, toYear)
+}
+
+// USFederalCalendar 美国联邦假日日历，按USFederalHolidayRules计算fromYear至toYear的假日。
+// 假日为周六时在前一个周五休息，为周日时在后一个周一休息，Date为实际休息日
+func USFederalCalendar(fromYear, toYear int) *Calendar {
+	return USFederalHolidayRules().Calendar(fromYear, toYear)
+}
+
+// JapanHolidayRules 日本国民祝日规则，按2019年起的《国民の祝日に関する法律》，
+// 包括振替休日和国民の休日、2019年天皇即位相关的休日，以及2020、2021年因东京奥运会调整的祝日。
+// 规则只适用于2019年及以后，更早的年份Holidays返回nil
+func JapanHolidayRules() HolidayRuleSet {
+	return HolidayRuleSet{
+		Name:           "Japan",
+		Rules:          mustParseHolidayRules(japanHolidayRules),
+		SubstituteName: "振替休日",
+		BridgeName:     "国民の休日",
+		FromYear:       japanHolidayRulesFromYear,
+	}
+}
+
+// japanHolidayRulesFromYear 日本祝日规则适用的起始年份，此前海の日、みどりの日等的日期不同
+const japanHolidayRulesFromYear = 2019
+
+// USFederalHolidayRules 美国联邦假日规则
+func USFederalHolidayRules() HolidayRuleSet {
+	return HolidayRuleSet{Name: "US Federal", Rules: mustParseHolidayRules(usFederalHolidayRules)}
+}
+
+const japanHolidayRules = `
+元日 = 01-01 observed=substitute
+成人の日 = 2nd mon jan
+建国記念の日 = 02-11 observed=substitute
+天皇誕生日 = 02-23 observed=substitute from=2020
+春分の日 = spring-equinox observed=substitute
+昭和の日 = 04-29 observed=substitute
+憲法記念日 = 05-03 observed=substitute
+みどりの日 = 05-04 observed=substitute
+こどもの日 = 05-05 observed=substitute
+即位の日 = 05-01 from=2019 to=2019
+即位礼正殿の儀 = 10-22 from=2019 to=2019
+海の日 = 3rd mon jul to=2019
+海の日 = 07-23 observed=substitute from=2020 to=2020
+海の日 = 07-22 observed=substitute from=2021 to=2021
+海の日 = 3rd mon jul from=2022
+山の日 = 08-11 observed=substitute to=2019
+山の日 = 08-10 observed=substitute from=2020 to=2020
+山の日 = 08-08 observed=substitute from=2021 to=2021
+山の日 = 08-11 observed=substitute from=2022
+敬老の日 = 3rd mon sep
+秋分の日 = autumn-equinox observed=substitute
+体育の日 = 2nd mon oct to=2019
+スポーツの日 = 07-24 observed=substitute from=2020 to=2020
+スポーツの日 = 07-23 observed=substitute from=2021 to=2021
+スポーツの日 = 2nd mon oct from=2022
+文化の日 = 11-03 observed=substitute
+勤労感謝の日 = 11-23 observed=substitute
+`
+
+const usFederalHolidayRules = `
+New Year's Day = 01-01 observed=nearest
+Martin Luther King Jr. Day = 3rd mon jan
+Washington's Birthday = 3rd mon feb
+Memorial Day = last mon may
+Juneteenth National Independence Day = 06-19 observed=nearest from=2021
+Independence Day = 07-04 observed=nearest
+Labor Day = 1st mon sep
+Columbus Day = 2nd mon oct
+Veterans Day = 11-11 observed=nearest
+Thanksgiving Day = 4th thu nov
+Christmas Day = 12-25 observed=nearest
+`
+
+// japanEquinoxDay 春分日(spring为true)或秋分日，适用于1980至2099年
+func japanEquinoxDay(year int, spring bool) int {
+	base := 23.2488
+	if spring {
+		base = 20.8431
+	}
+	return int(base+0.242194*float64(year-1980)) - (year-1980)/4
+}
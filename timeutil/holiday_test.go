@@ -0,0 +1,189 @@
+package timeutil
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestChinaCalendar(t *testing.T) {
+	loc := getTestTimezone()
+	c := ChinaCalendar()
+	tests := []struct {
+		day     string
+		workday bool
+		holiday bool
+		name    string
+	}{
+		{"20240101", false, true, "元旦"},
+		{"20240204", true, false, "春节"}, // 周日调休上班
+		{"20240212", false, true, "春节"},
+		{"20240218", true, false, "春节"},
+		{"20240219", true, false, ""},
+		{"20240727", false, false, ""},
+		{"20241007", false, true, "国庆节"},
+		{"20241012", true, false, "国庆节"},
+		{"20200131", false, true, "春节"}, // 疫情延长的假期
+		{"20221231", false, true, "元旦"},
+		{"20251008", false, true, "国庆节、中秋节"},
+		{"20260104", true, false, "元旦"},
+		{"20260223", false, true, "春节"},
+		{"20260228", true, false, "春节"},
+		{"20260619", false, true, "端午节"},
+		{"20260925", false, true, "中秋节"},
+		{"20261010", true, false, "国庆节"},
+	}
+	for _, test := range tests {
+		tm := Str2Time(test.day, FormatYYYYMMDDNoSymbol, loc)
+		if got := c.IsWorkday(tm, loc); got != test.workday {
+			t.Errorf("IsWorkday(%s) = %v; want %v", test.day, got, test.workday)
+		}
+		if got := c.IsHoliday(tm, loc); got != test.holiday {
+			t.Errorf("IsHoliday(%s) = %v; want %v", test.day, got, test.holiday)
+		}
+		if name, _ := c.HolidayName(tm, loc); name != test.name {
+			t.Errorf("HolidayName(%s) = %q; want %q", test.day, name, test.name)
+		}
+	}
+
+	for year, expected := range map[string]int{"2024": 251, "2025": 248, "2026": 248} {
+		r := NewDateRangeBiDay(year+"0101", year+"1231", loc)
+		if got := c.CountWorkdays(r, loc); got != expected {
+			t.Errorf("CountWorkdays(%s) = %d; want %d", year, got, expected)
+		}
+	}
+	if got := c.AddWorkdaysBiDay("20240930", 1, loc); got != "20241008" {
+		t.Errorf("AddWorkdaysBiDay(20240930, 1) = %s; want 20241008", got)
+	}
+	if got := c.AddWorkdaysBiDay("20241008", -1, loc); got != "20240930" {
+		t.Errorf("AddWorkdaysBiDay(20241008, -1) = %s; want 20240930", got)
+	}
+	if got := c.NextWorkday(Str2Time("20240209", FormatYYYYMMDDNoSymbol, loc), loc); got.Format(FormatYYYYMMDDNoSymbol) != "20240218" {
+		t.Errorf("NextWorkday(20240209) = %v; want 20240218", got)
+	}
+	if !c.IsWorkdayBiDay("20240929", loc) {
+		t.Errorf("IsWorkdayBiDay(20240929) = false; want true")
+	}
+	if years := c.Years(); years[0] != 2019 || years[len(years)-1] != 2026 || len(years) != 8 {
+		t.Errorf("Years() = %v; want 2019..2026", years)
+	}
+	if c.Covers(2018) || !c.Covers(2026) || c.Covers(2027) {
+		t.Errorf("Covers() should be true only for 2019..2026")
+	}
+}
+
+func TestParseHolidayData(t *testing.T) {
+	data := `
+# 注释
+2030-02-01~2030-02-03 holiday 春节
+2030-01-27 workday 春节
+`
+	holidays, err := ParseHolidayData(strings.NewReader(data))
+	if err != nil || len(holidays) != 4 {
+		t.Fatalf("ParseHolidayData() = %v, %v; want 4 days", holidays, err)
+	}
+	if h := holidays[3]; h.Day() != "20300127" || h.Kind != HolidayWorkday || h.Name != "春节" {
+		t.Errorf("holidays[3] = %+v", h)
+	}
+
+	for _, bad := range []string{"2030-02-01 holiday", "2030-02-01 vacation 春节", "2030-02-30 holiday 春节", "2030-02-03~2030-02-01 holiday 春节"} {
+		if _, err := ParseHolidayData(strings.NewReader(bad)); err == nil {
+			t.Errorf("ParseHolidayData(%q) error = nil; want error", bad)
+		}
+	}
+
+	loc := getTestTimezone()
+	c := ChinaCalendar().Overlay(NewCalendar("China 2030", holidays))
+	if c.IsWorkdayBiDay("20300201", loc) || !c.IsWorkdayBiDay("20300127", loc) || c.IsWorkdayBiDay("20240101", loc) {
+		t.Errorf("overlaid calendar should include both datasets")
+	}
+	if c.Name() != "China+China 2030" {
+		t.Errorf("Name() = %q", c.Name())
+	}
+	if !c.Covers(2026) || c.Covers(2029) || !c.Covers(2030) {
+		t.Errorf("overlaid calendar Years() = %v; want 2019..2026 and 2030", c.Years())
+	}
+}
+
+func TestJapanCalendar(t *testing.T) {
+	c := JapanCalendar(2020, 2026)
+	expected2024 := []string{
+		"20240101", "20240108", "20240211", "20240212", "20240223", "20240320", "20240429",
+		"20240503", "20240504", "20240505", "20240506", "20240715", "20240811", "20240812",
+		"20240916", "20240922", "20240923", "20241014", "20241103", "20241104", "20241123",
+	}
+	var got []string
+	for _, h := range c.Holidays(2024) {
+		got = append(got, h.Day())
+	}
+	if strings.Join(got, ",") != strings.Join(expected2024, ",") {
+		t.Errorf("Holidays(2024) = %v; want %v", got, expected2024)
+	}
+
+	loc := TimezoneJp
+	for day, name := range map[string]string{
+		"20210723": "スポーツの日",
+		"20210809": "振替休日",
+		"20260922": "国民の休日",
+		"20250320": "春分の日",
+		"20250923": "秋分の日",
+	} {
+		if got, _ := c.HolidayName(Str2Time(day, FormatYYYYMMDDNoSymbol, loc), loc); got != name {
+			t.Errorf("HolidayName(%s) = %q; want %q", day, got, name)
+		}
+	}
+
+	c = JapanCalendar(2000, 2019)
+	if years := c.Years(); len(years) != 1 || years[0] != 2019 {
+		t.Errorf("JapanCalendar(2000, 2019).Years() = %v; want [2019]", years)
+	}
+	for day, name := range map[string]string{
+		"20190430": "国民の休日",
+		"20190501": "即位の日",
+		"20190502": "国民の休日",
+		"20190506": "振替休日",
+		"20191022": "即位礼正殿の儀",
+	} {
+		if got, _ := c.HolidayName(Str2Time(day, FormatYYYYMMDDNoSymbol, loc), loc); got != name {
+			t.Errorf("HolidayName(%s) = %q; want %q", day, got, name)
+		}
+	}
+	if got := JapanHolidayRules().Holidays(2018); got != nil {
+		t.Errorf("JapanHolidayRules().Holidays(2018) = %v; want nil", got)
+	}
+}
+
+func TestUSFederalCalendar(t *testing.T) {
+	c := USFederalCalendar(2022, 2024)
+	loc := TimezoneLa
+	tests := []struct {
+		day  string
+		name string
+	}{
+		{"20220620", "Juneteenth National Independence Day"},
+		{"20221226", "Christmas Day"},
+		{"20231123", "Thanksgiving Day"},
+		{"20240527", "Memorial Day"},
+		{"20240902", "Labor Day"},
+	}
+	for _, test := range tests {
+		tm := Str2Time(test.day, FormatYYYYMMDDNoSymbol, loc)
+		if got, _ := c.HolidayName(tm, loc); got != test.name || c.IsWorkday(tm, loc) {
+			t.Errorf("HolidayName(%s) = %q; want %q and non-workday", test.day, got, test.name)
+		}
+	}
+	if got := len(c.Holidays(2023)); got != 11 {
+		t.Errorf("len(Holidays(2023)) = %d; want 11", got)
+	}
+	if got := c.AddWorkdays(time.Date(2024, 7, 3, 12, 0, 0, 0, loc), 1, loc); got.Day() != 5 {
+		t.Errorf("AddWorkdays(2024-07-03, 1) = %v; want 2024-07-05", got)
+	}
+	// 2022-01-01为周六，补休日2021-12-31属于2021年
+	newYearObserved := time.Date(2021, 12, 31, 12, 0, 0, 0, loc)
+	if c.IsHoliday(newYearObserved, loc) {
+		t.Errorf("USFederalCalendar(2022, 2024).IsHoliday(2021-12-31) = true; want false")
+	}
+	if got, _ := USFederalCalendar(2021, 2021).HolidayName(newYearObserved, loc); got != "New Year's Day" {
+		t.Errorf("USFederalCalendar(2021, 2021).HolidayName(2021-12-31) = %q; want %q", got, "New Year's Day")
+	}
+}
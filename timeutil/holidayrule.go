@@ -0,0 +1,396 @@
+package timeutil
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ObservedPolicy 节日落在周末时的调整方式
+type ObservedPolicy string
+
+const (
+	ObservedNone       ObservedPolicy = "none"       // 不调整
+	ObservedNearest    ObservedPolicy = "nearest"    // 周六提前到周五，周日顺延到周一，例如美国联邦假日
+	ObservedMonday     ObservedPolicy = "monday"     // 周六、周日均顺延到周一
+	ObservedSubstitute ObservedPolicy = "substitute" // 保留原日期，周日时另外在之后第一个非节日补休，例如日本振替休日
+)
+
+// HolidayRule 按规则计算的节日，通过FixedHoliday、NthWeekdayHoliday、EasterHoliday、LunarHoliday等创建
+type HolidayRule struct {
+	Name     string         // 节日名称
+	Observed ObservedPolicy // 落在周末时的调整方式
+	FromYear int            // 生效的第一年，0表示不限
+	ToYear   int            // 生效的最后一年，0表示不限
+	Offset   int            // 在计算出的日期上偏移的天数，例如除夕为春节偏移-1
+	Days     int            // 连续放假的天数，<=1时为1天
+
+	date func(year int) (time.Time, bool)
+}
+
+// FixedHoliday 每年固定月日的节日
+func FixedHoliday(name string, month time.Month, day int) HolidayRule {
+	return FuncHoliday(name, func(year int) (time.Time, bool) {
+		if day < 1 || day > daysInMonth(year, month) {
+			return time.Time{}, false
+		}
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC), true
+	})
+}
+
+// NthWeekdayHoliday 每年某月第n个星期几的节日，n为负数时从月末倒数，例如感恩节为NthWeekdayHoliday("Thanksgiving Day", time.November, time.Thursday, 4)
+func NthWeekdayHoliday(name string, month time.Month, weekday time.Weekday, n int) HolidayRule {
+	return FuncHoliday(name, func(year int) (time.Time, bool) {
+		return NthWeekdayOfMonth(year, month, weekday, n, time.UTC)
+	})
+}
+
+// EasterHoliday 相对复活节(西方教会，格里高利历)偏移offset天的节日，例如耶稣受难日为EasterHoliday("Good Friday", -2)
+func EasterHoliday(name string, offset int) HolidayRule {
+	return FuncHoliday(name, func(year int) (time.Time, bool) {
+		return EasterSunday(year).AddDate(0, 0, offset), true
+	})
+}
+
+// LunarHoliday 农历节日，例如中秋节为LunarHoliday("中秋节", 8, 15)；农历月的最后一天可用下个月初一加Offset -1表示
+func LunarHoliday(name string, month, day int) HolidayRule {
+	return FuncHoliday(name, func(year int) (time.Time, bool) {
+		return LunarToSolar(year, month, day, false)
+	})
+}
+
+// EquinoxHoliday 日本的春分日(spring为true)或秋分日，按天文计算的近似公式，适用于1980至2099年
+func EquinoxHoliday(name string, spring bool) HolidayRule {
+	return FuncHoliday(name, func(year int) (time.Time, bool) {
+		month := time.September
+		if spring {
+			month = time.March
+		}
+		return time.Date(year, month, japanEquinoxDay(year, spring), 0, 0, 0, 0, time.UTC), true
+	})
+}
+
+// FuncHoliday 由函数计算日期的节日，f返回UTC零点表示的自然日，当年没有该节日时返回false
+func FuncHoliday(name string, f func(year int) (time.Time, bool)) HolidayRule {
+	return HolidayRule{Name: name, date: f}
+}
+
+// Between 只在fromYear至toYear年生效，0表示不限
+func (r HolidayRule) Between(fromYear, toYear int) HolidayRule {
+	r.FromYear, r.ToYear = fromYear, toYear
+	return r
+}
+
+// WithObserved 设置落在周末时的调整方式
+func (r HolidayRule) WithObserved(policy ObservedPolicy) HolidayRule {
+	r.Observed = policy
+	return r
+}
+
+// WithOffset 设置偏移天数
+func (r HolidayRule) WithOffset(days int) HolidayRule {
+	r.Offset = days
+	return r
+}
+
+// WithDays 设置连续放假的天数
+func (r HolidayRule) WithDays(days int) HolidayRule {
+	r.Days = days
+	return r
+}
+
+// Dates 节日在year年的日期(未按Observed调整)，不生效时返回nil
+func (r HolidayRule) Dates(year int) []time.Time {
+	if r.date == nil || (r.FromYear > 0 && year < r.FromYear) || (r.ToYear > 0 && year > r.ToYear) {
+		return nil
+	}
+	start, ok := r.date(year)
+	if !ok {
+		return nil
+	}
+	start = start.AddDate(0, 0, r.Offset)
+	dates := []time.Time{start}
+	for i := 1; i < r.Days; i++ {
+		dates = append(dates, start.AddDate(0, 0, i))
+	}
+	return dates
+}
+
+// EasterSunday year年的复活节(西方教会，格里高利历)，UTC零点表示的自然日
+func EasterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// HolidayRuleSet 一组节日规则，例如一个国家的法定节假日
+type HolidayRuleSet struct {
+	Name           string        // 日历名称
+	Rules          []HolidayRule // 节日规则
+	SubstituteName string        // ObservedSubstitute产生的补休日名称，为空时为"<节日名称> (observed)"
+	BridgeName     string        // 不为空时，前后均为节日(不含补休日)的平日也放假并使用该名称，例如日本的国民の休日
+	FromYear       int           // 规则适用的起始年份，为0时不限
+	ToYear         int           // 规则适用的结束年份，为0时不限
+}
+
+// covers 规则是否适用于year年
+func (s HolidayRuleSet) covers(year int) bool {
+	return (s.FromYear == 0 || year >= s.FromYear) && (s.ToYear == 0 || year <= s.ToYear)
+}
+
+// Holidays 按规则生成year年的节假日，按日期排序；year不在FromYear至ToYear内时返回nil
+func (s HolidayRuleSet) Holidays(year int) []Holiday {
+	if !s.covers(year) {
+		return nil
+	}
+	var holidays []Holiday
+	taken := make(map[int]bool)
+	statutory := make(map[int]bool) // 节日本身，不含补休日
+	var substitutes []Holiday
+	for _, rule := range s.Rules {
+		for _, d := range rule.Dates(year) {
+			switch rule.Observed {
+			case ObservedNearest:
+				if d.Weekday() == time.Saturday {
+					d = d.AddDate(0, 0, -1)
+				} else if d.Weekday() == time.Sunday {
+					d = d.AddDate(0, 0, 1)
+				}
+			case ObservedMonday:
+				if d.Weekday() == time.Saturday {
+					d = d.AddDate(0, 0, 2)
+				} else if d.Weekday() == time.Sunday {
+					d = d.AddDate(0, 0, 1)
+				}
+			case ObservedSubstitute:
+				if d.Weekday() == time.Sunday {
+					substitutes = append(substitutes, Holiday{Date: d, Name: rule.Name, Kind: HolidayOff})
+				}
+			}
+			taken[holidayKey(d)] = true
+			statutory[holidayKey(d)] = true
+			holidays = append(holidays, Holiday{Date: d, Name: rule.Name, Kind: HolidayOff})
+		}
+	}
+
+	for _, h := range substitutes {
+		d := h.Date.AddDate(0, 0, 1)
+		for taken[holidayKey(d)] {
+			d = d.AddDate(0, 0, 1)
+		}
+		name := s.SubstituteName
+		if name == "" {
+			name = h.Name + " (observed)"
+		}
+		taken[holidayKey(d)] = true
+		holidays = append(holidays, Holiday{Date: d, Name: name, Kind: HolidayOff})
+	}
+
+	if s.BridgeName != "" {
+		for _, h := range holidays {
+			d := h.Date.AddDate(0, 0, 1)
+			if statutory[holidayKey(h.Date)] && !taken[holidayKey(d)] && d.Weekday() != time.Sunday && statutory[holidayKey(d.AddDate(0, 0, 1))] {
+				taken[holidayKey(d)] = true
+				holidays = append(holidays, Holiday{Date: d, Name: s.BridgeName, Kind: HolidayOff})
+			}
+		}
+	}
+	sort.SliceStable(holidays, func(i, j int) bool { return holidays[i].Date.Before(holidays[j].Date) })
+	return holidays
+}
+
+// Calendar 按规则生成fromYear至toYear年的工作日历，年份范围限制在FromYear至ToYear内，范围外的日期不收录
+func (s HolidayRuleSet) Calendar(fromYear, toYear int) *Calendar {
+	if s.FromYear != 0 && fromYear < s.FromYear {
+		fromYear = s.FromYear
+	}
+	if s.ToYear != 0 && toYear > s.ToYear {
+		toYear = s.ToYear
+	}
+	// 调整后的休息日可能落在相邻年份，需要多算前后各一年，只保留范围内的日期
+	var holidays []Holiday
+	for y := fromYear - 1; y <= toYear+1; y++ {
+		for _, h := range s.Holidays(y) {
+			if year := h.Date.Year(); year >= fromYear && year <= toYear {
+				holidays = append(holidays, h)
+			}
+		}
+	}
+	c := NewCalendar(s.Name, holidays)
+	c.years = make(map[int]bool, toYear-fromYear+1)
+	for y := fromYear; y <= toYear; y++ {
+		c.years[y] = true
+	}
+	return c
+}
+
+// ParseHolidayRules 解析文本格式的节日规则，每行格式为"名称 = 日期规则 [选项...]"，例如：
+//
+//	元日 = 01-01 observed=substitute
+//	Thanksgiving Day = 4th thu nov
+//	Memorial Day = last mon may observed=nearest
+//	Good Friday = easter-2
+//	中秋节 = lunar 08-15
+//	除夕 = lunar 01-01 offset=-1
+//	春分の日 = spring-equinox
+//
+// 日期规则为MM-DD、"<第几个> <星期> <月份>"(1st至5th或last，星期和月份为英文缩写或数字)、easter[±N]、lunar MM-DD、spring-equinox、autumn-equinox；
+// 选项为observed=none|nearest|monday|substitute、from=YYYY、to=YYYY、offset=±N、days=N。空行和#开头的注释行忽略
+func ParseHolidayRules(r io.Reader) ([]HolidayRule, error) {
+	var rules []HolidayRule
+	scanner := bufio.NewScanner(r)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule, err := parseHolidayRule(line)
+		if err != nil {
+			return nil, fmt.Errorf("timeutil: holiday rule line %d: %w", lineNo, err)
+		}
+		rules = append(rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func parseHolidayRule(line string) (HolidayRule, error) {
+	name, spec, ok := strings.Cut(line, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return HolidayRule{}, fmt.Errorf("expected \"name = rule\", got %q", line)
+	}
+	var specFields []string
+	var options []string
+	for _, field := range strings.Fields(strings.ToLower(spec)) {
+		if strings.Contains(field, "=") {
+			options = append(options, field)
+		} else {
+			specFields = append(specFields, field)
+		}
+	}
+
+	rule, err := parseHolidayDateSpec(name, specFields)
+	if err != nil {
+		return HolidayRule{}, err
+	}
+	for _, option := range options {
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "observed":
+			policy := ObservedPolicy(value)
+			if policy != ObservedNone && policy != ObservedNearest && policy != ObservedMonday && policy != ObservedSubstitute {
+				return HolidayRule{}, fmt.Errorf("unknown observed policy %q", value)
+			}
+			rule.Observed = policy
+		case "from", "to", "offset", "days":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return HolidayRule{}, fmt.Errorf("bad %s %q", key, value)
+			}
+			switch key {
+			case "from":
+				rule.FromYear = n
+			case "to":
+				rule.ToYear = n
+			case "offset":
+				rule.Offset = n
+			case "days":
+				rule.Days = n
+			}
+		default:
+			return HolidayRule{}, fmt.Errorf("unknown option %q", key)
+		}
+	}
+	return rule, nil
+}
+
+var (
+	holidayOrdinals = map[string]int{"1st": 1, "2nd": 2, "3rd": 3, "4th": 4, "5th": 5, "last": -1}
+	holidayWeekdays = map[string]time.Weekday{
+		"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+		"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+	}
+	holidayMonths = map[string]time.Month{
+		"jan": time.January, "feb": time.February, "mar": time.March, "apr": time.April,
+		"may": time.May, "jun": time.June, "jul": time.July, "aug": time.August,
+		"sep": time.September, "oct": time.October, "nov": time.November, "dec": time.December,
+	}
+)
+
+func parseHolidayDateSpec(name string, fields []string) (HolidayRule, error) {
+	spec := strings.Join(fields, " ")
+	switch {
+	case len(fields) == 1 && strings.HasPrefix(spec, "easter"):
+		offset := 0
+		if rest := strings.TrimPrefix(spec, "easter"); rest != "" {
+			n, err := strconv.Atoi(rest)
+			if err != nil {
+				return HolidayRule{}, fmt.Errorf("bad easter offset %q", spec)
+			}
+			offset = n
+		}
+		return EasterHoliday(name, offset), nil
+	case spec == "spring-equinox" || spec == "autumn-equinox":
+		return EquinoxHoliday(name, spec == "spring-equinox"), nil
+	case len(fields) == 2 && fields[0] == "lunar":
+		month, day, err := parseMonthDay(fields[1])
+		if err != nil {
+			return HolidayRule{}, err
+		}
+		return LunarHoliday(name, month, day), nil
+	case len(fields) == 1:
+		month, day, err := parseMonthDay(fields[0])
+		if err != nil {
+			return HolidayRule{}, err
+		}
+		return FixedHoliday(name, time.Month(month), day), nil
+	case len(fields) == 3:
+		n, ok1 := holidayOrdinals[fields[0]]
+		weekday, ok2 := holidayWeekdays[fields[1]]
+		month, ok3 := holidayMonths[fields[2]]
+		if !ok3 {
+			if m, err := strconv.Atoi(fields[2]); err == nil && m >= 1 && m <= 12 {
+				month, ok3 = time.Month(m), true
+			}
+		}
+		if ok1 && ok2 && ok3 {
+			return NthWeekdayHoliday(name, month, weekday, n), nil
+		}
+	}
+	return HolidayRule{}, fmt.Errorf("bad date rule %q", spec)
+}
+
+func parseMonthDay(s string) (int, int, error) {
+	ms, ds, ok := strings.Cut(s, "-")
+	month, err1 := strconv.Atoi(ms)
+	day, err2 := strconv.Atoi(ds)
+	if !ok || err1 != nil || err2 != nil || month < 1 || month > 12 || day < 1 || day > 31 {
+		return 0, 0, fmt.Errorf("bad month-day %q", s)
+	}
+	return month, day, nil
+}
+
+// mustParseHolidayRules 解析内置的节日规则
+func mustParseHolidayRules(text string) []HolidayRule {
+	rules, err := ParseHolidayRules(strings.NewReader(text))
+	if err != nil {
+		panic(err)
+	}
+	return rules
+}
@@ -0,0 +1,142 @@
+package timeutil
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEasterSunday(t *testing.T) {
+	for year, expected := range map[int]string{2000: "20000423", 2019: "20190421", 2024: "20240331", 2025: "20250420", 2038: "20380425"} {
+		if got := EasterSunday(year).Format(FormatYYYYMMDDNoSymbol); got != expected {
+			t.Errorf("EasterSunday(%d) = %s; want %s", year, got, expected)
+		}
+	}
+}
+
+func TestHolidayRuleDates(t *testing.T) {
+	days := func(dates []time.Time) string {
+		var ret []string
+		for _, d := range dates {
+			ret = append(ret, d.Format(FormatYYYYMMDDNoSymbol))
+		}
+		return strings.Join(ret, ",")
+	}
+	tests := []struct {
+		rule     HolidayRule
+		year     int
+		expected string
+	}{
+		{FixedHoliday("Christmas Day", time.December, 25), 2024, "20241225"},
+		{FixedHoliday("Leap Day", time.February, 29), 2023, ""},
+		{NthWeekdayHoliday("Thanksgiving Day", time.November, time.Thursday, 4), 2024, "20241128"},
+		{NthWeekdayHoliday("Memorial Day", time.May, time.Monday, -1), 2024, "20240527"},
+		{EasterHoliday("Good Friday", -2), 2024, "20240329"},
+		{EasterHoliday("Easter Monday", 1), 2025, "20250421"},
+		{LunarHoliday("春节", 1, 1).WithDays(3), 2024, "20240210,20240211,20240212"},
+		{LunarHoliday("除夕", 1, 1).WithOffset(-1), 2025, "20250128"},
+		{LunarHoliday("中秋节", 8, 15), 2023, "20230929"},
+		{EquinoxHoliday("春分の日", true), 2025, "20250320"},
+		{FixedHoliday("Juneteenth", time.June, 19).Between(2021, 0), 2020, ""},
+	}
+	for _, test := range tests {
+		if got := days(test.rule.Dates(test.year)); got != test.expected {
+			t.Errorf("%s.Dates(%d) = %s; want %s", test.rule.Name, test.year, got, test.expected)
+		}
+	}
+}
+
+func TestLunarHolidaysMatchChinaCalendar(t *testing.T) {
+	loc := getTestTimezone()
+	china := ChinaCalendar()
+	rules := []HolidayRule{LunarHoliday("春节", 1, 1), LunarHoliday("端午节", 5, 5), LunarHoliday("中秋节", 8, 15)}
+	for year := 2019; year <= 2025; year++ {
+		for _, rule := range rules {
+			for _, d := range rule.Dates(year) {
+				name, _ := china.HolidayName(d, loc)
+				if !china.IsHoliday(time.Date(d.Year(), d.Month(), d.Day(), 12, 0, 0, 0, loc), loc) || !strings.Contains(name, rule.Name) {
+					t.Errorf("%s %d = %s is not a %s holiday in ChinaCalendar (%q)", rule.Name, year, d.Format(FormatYYYYMMDDNoSymbol), rule.Name, name)
+				}
+			}
+		}
+	}
+}
+
+func TestHolidayRuleSetObserved(t *testing.T) {
+	set := HolidayRuleSet{
+		Name: "test",
+		Rules: []HolidayRule{
+			FixedHoliday("Sat Nearest", time.July, 6).WithObserved(ObservedNearest),
+			FixedHoliday("Sun Nearest", time.July, 14).WithObserved(ObservedNearest),
+			FixedHoliday("Sat Monday", time.July, 20).WithObserved(ObservedMonday),
+			FixedHoliday("Sun Substitute", time.July, 28).WithObserved(ObservedSubstitute),
+			FixedHoliday("Mon", time.July, 29),
+			FixedHoliday("Thu", time.August, 1),
+			FixedHoliday("Tue", time.August, 6),
+			FixedHoliday("Thu", time.August, 8),
+		},
+		BridgeName: "Bridge",
+	}
+	var got []string
+	for _, h := range set.Holidays(2024) {
+		got = append(got, h.Day()+" "+h.Name)
+	}
+	expected := []string{
+		"20240705 Sat Nearest",
+		"20240715 Sun Nearest",
+		"20240722 Sat Monday",
+		"20240728 Sun Substitute",
+		"20240729 Mon",
+		"20240730 Sun Substitute (observed)",
+		"20240801 Thu", // 07-31前一天是补休日，不算夹在节日之间
+		"20240806 Tue",
+		"20240807 Bridge",
+		"20240808 Thu",
+	}
+	if strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Errorf("Holidays(2024) = %q; want %q", got, expected)
+	}
+}
+
+func TestParseHolidayRules(t *testing.T) {
+	text := `
+# 自定义规则
+Founders Day = 2nd fri 3 observed=nearest from=2020
+Good Friday = easter-2
+除夕 = lunar 01-01 offset=-1
+春节 = lunar 01-01 days=3
+Boxing Day = 12-26 observed=monday to=2030
+`
+	rules, err := ParseHolidayRules(strings.NewReader(text))
+	if err != nil || len(rules) != 5 {
+		t.Fatalf("ParseHolidayRules() = %d rules, %v; want 5", len(rules), err)
+	}
+	set := HolidayRuleSet{Name: "custom", Rules: rules}
+	c := set.Calendar(2024, 2024)
+	for day, name := range map[string]string{
+		"20240308": "Founders Day",
+		"20240329": "Good Friday",
+		"20240209": "除夕",
+		"20240212": "春节",
+		"20241226": "Boxing Day",
+	} {
+		if got, _ := c.HolidayName(Str2Time(day, FormatYYYYMMDDNoSymbol, time.UTC), time.UTC); got != name {
+			t.Errorf("HolidayName(%s) = %q; want %q", day, got, name)
+		}
+	}
+
+	for _, bad := range []string{
+		"no rule",
+		"X = 13-01",
+		"X = 6th mon jan",
+		"X = 1st mon foo",
+		"X = easter+x",
+		"X = 01-01 observed=never",
+		"X = 01-01 from=abc",
+		"X = 01-01 color=red",
+	} {
+		if _, err := ParseHolidayRules(strings.NewReader(bad)); err == nil {
+			t.Errorf("ParseHolidayRules(%q) error = nil; want error", bad)
+		}
+	}
+}
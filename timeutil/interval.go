@@ -0,0 +1,220 @@
+package timeutil
+
+import (
+	"sort"
+	"time"
+)
+
+// Interval 左闭右开的时间区间 [Start, End)
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// NewInterval 创建时间区间，start晚于end时自动交换
+func NewInterval(start, end time.Time) Interval {
+	if end.Before(start) {
+		start, end = end, start
+	}
+	return Interval{Start: start, End: end}
+}
+
+// DayInterval YYYYMMDD格式的日期对应的整天区间 [当天零点, 次日零点)
+func DayInterval(day string, timezone *time.Location) Interval {
+	start := Str2Time(day, FormatYYYYMMDDNoSymbol, timezone)
+	return Interval{Start: start, End: start.AddDate(0, 0, 1)}
+}
+
+// IsEmpty 区间是否为空
+func (i Interval) IsEmpty() bool {
+	return !i.Start.Before(i.End)
+}
+
+// Duration 区间时长
+func (i Interval) Duration() time.Duration {
+	if i.IsEmpty() {
+		return 0
+	}
+	return i.End.Sub(i.Start)
+}
+
+// Contains 判断t是否落在区间内
+func (i Interval) Contains(t time.Time) bool {
+	return !t.Before(i.Start) && t.Before(i.End)
+}
+
+// Overlaps 判断两个区间是否有交集
+func (i Interval) Overlaps(o Interval) bool {
+	return i.Start.Before(o.End) && o.Start.Before(i.End)
+}
+
+// Intersect 两个区间的交集，无交集时返回空区间
+func (i Interval) Intersect(o Interval) Interval {
+	start, end := i.Start, i.End
+	if o.Start.After(start) {
+		start = o.Start
+	}
+	if o.End.Before(end) {
+		end = o.End
+	}
+	if end.Before(start) {
+		end = start
+	}
+	return Interval{Start: start, End: end}
+}
+
+// MergeIntervals 合并重叠或相邻的区间，返回按起点排序的结果，空区间会被丢弃
+func MergeIntervals(intervals []Interval) []Interval {
+	sorted := make([]Interval, 0, len(intervals))
+	for _, iv := range intervals {
+		if !iv.IsEmpty() {
+			sorted = append(sorted, iv)
+		}
+	}
+	sort.Slice(sorted, func(a, b int) bool {
+		return sorted[a].Start.Before(sorted[b].Start)
+	})
+
+	var ret []Interval
+	for _, iv := range sorted {
+		last := len(ret) - 1
+		if last >= 0 && !iv.Start.After(ret[last].End) {
+			if iv.End.After(ret[last].End) {
+				ret[last].End = iv.End
+			}
+			continue
+		}
+		ret = append(ret, iv)
+	}
+	return ret
+}
+
+// IntervalSet 时间区间集合，内部区间互不重叠、互不相邻且按起点排序。零值为空集合
+type IntervalSet struct {
+	intervals []Interval
+}
+
+// NewIntervalSet 由任意区间创建集合，重叠或相邻的区间会被合并
+func NewIntervalSet(intervals ...Interval) IntervalSet {
+	return IntervalSet{intervals: MergeIntervals(intervals)}
+}
+
+// Intervals 返回集合中的区间副本
+func (s IntervalSet) Intervals() []Interval {
+	ret := make([]Interval, len(s.intervals))
+	copy(ret, s.intervals)
+	return ret
+}
+
+// IsEmpty 集合是否为空
+func (s IntervalSet) IsEmpty() bool {
+	return len(s.intervals) == 0
+}
+
+// Add 向集合中加入区间，返回新集合
+func (s IntervalSet) Add(intervals ...Interval) IntervalSet {
+	all := make([]Interval, 0, len(s.intervals)+len(intervals))
+	all = append(all, s.intervals...)
+	all = append(all, intervals...)
+	return NewIntervalSet(all...)
+}
+
+// Union 并集
+func (s IntervalSet) Union(o IntervalSet) IntervalSet {
+	return s.Add(o.intervals...)
+}
+
+// Intersect 交集
+func (s IntervalSet) Intersect(o IntervalSet) IntervalSet {
+	var ret []Interval
+	i, j := 0, 0
+	for i < len(s.intervals) && j < len(o.intervals) {
+		iv := s.intervals[i].Intersect(o.intervals[j])
+		if !iv.IsEmpty() {
+			ret = append(ret, iv)
+		}
+		// 先结束的区间不会再与后续区间相交
+		if s.intervals[i].End.Before(o.intervals[j].End) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return IntervalSet{intervals: ret}
+}
+
+// Difference 差集，属于s但不属于o的部分
+func (s IntervalSet) Difference(o IntervalSet) IntervalSet {
+	var ret []Interval
+	j := 0
+	for _, iv := range s.intervals {
+		start := iv.Start
+		for j < len(o.intervals) && !o.intervals[j].End.After(start) {
+			j++
+		}
+		for k := j; k < len(o.intervals) && o.intervals[k].Start.Before(iv.End); k++ {
+			cut := o.intervals[k]
+			if cut.Start.After(start) {
+				ret = append(ret, Interval{Start: start, End: cut.Start})
+			}
+			if cut.End.After(start) {
+				start = cut.End
+			}
+		}
+		if start.Before(iv.End) {
+			ret = append(ret, Interval{Start: start, End: iv.End})
+		}
+	}
+	return IntervalSet{intervals: ret}
+}
+
+// Complement 在bounds范围内的补集
+func (s IntervalSet) Complement(bounds Interval) IntervalSet {
+	return NewIntervalSet(bounds).Difference(s)
+}
+
+// Gaps 在bounds范围内未被覆盖的空档
+func (s IntervalSet) Gaps(bounds Interval) []Interval {
+	return s.Complement(bounds).intervals
+}
+
+// Duration 集合覆盖的总时长
+func (s IntervalSet) Duration() time.Duration {
+	var total time.Duration
+	for _, iv := range s.intervals {
+		total += iv.Duration()
+	}
+	return total
+}
+
+// Contains 判断t是否被集合覆盖
+func (s IntervalSet) Contains(t time.Time) bool {
+	idx := sort.Search(len(s.intervals), func(i int) bool {
+		return s.intervals[i].End.After(t)
+	})
+	return idx < len(s.intervals) && s.intervals[idx].Contains(t)
+}
+
+// Covers 判断区间iv是否被集合完整覆盖
+func (s IntervalSet) Covers(iv Interval) bool {
+	if iv.IsEmpty() {
+		return true
+	}
+	idx := sort.Search(len(s.intervals), func(i int) bool {
+		return s.intervals[i].End.After(iv.Start)
+	})
+	return idx < len(s.intervals) &&
+		!s.intervals[idx].Start.After(iv.Start) &&
+		!s.intervals[idx].End.Before(iv.End)
+}
+
+// MissingBiDays 获取从某日到某日(包括起止点)中未被集合完整覆盖的日期，格式为YYYYMMDD
+func MissingBiDays(s IntervalSet, from string, to string, timezone *time.Location) []string {
+	var ret []string
+	for _, day := range GetRangeBiDay(from, to) {
+		if !s.Covers(DayInterval(day, timezone)) {
+			ret = append(ret, day)
+		}
+	}
+	return ret
+}
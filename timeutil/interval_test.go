@@ -0,0 +1,94 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+// Helper function to create an interval of hours on 2024-07-01
+func hourInterval(from, to int) Interval {
+	base := time.Date(2024, time.July, 1, 0, 0, 0, 0, TimezoneShanghai)
+	return Interval{Start: base.Add(time.Duration(from) * time.Hour), End: base.Add(time.Duration(to) * time.Hour)}
+}
+
+func equalIntervals(a, b []Interval) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Start.Equal(b[i].Start) || !a[i].End.Equal(b[i].End) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMergeIntervals(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []Interval
+		expected []Interval
+	}{
+		{"overlapping", []Interval{hourInterval(1, 3), hourInterval(2, 5)}, []Interval{hourInterval(1, 5)}},
+		{"adjacent", []Interval{hourInterval(3, 4), hourInterval(1, 3)}, []Interval{hourInterval(1, 4)}},
+		{"disjoint", []Interval{hourInterval(6, 7), hourInterval(1, 2)}, []Interval{hourInterval(1, 2), hourInterval(6, 7)}},
+		{"empty dropped", []Interval{hourInterval(2, 2), hourInterval(4, 5)}, []Interval{hourInterval(4, 5)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MergeIntervals(tt.input); !equalIntervals(got, tt.expected) {
+				t.Errorf("MergeIntervals() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIntervalSetAlgebra(t *testing.T) {
+	a := NewIntervalSet(hourInterval(0, 4), hourInterval(6, 10))
+	b := NewIntervalSet(hourInterval(2, 7), hourInterval(9, 12))
+
+	tests := []struct {
+		name     string
+		got      IntervalSet
+		expected []Interval
+	}{
+		{"union", a.Union(b), []Interval{hourInterval(0, 12)}},
+		{"intersect", a.Intersect(b), []Interval{hourInterval(2, 4), hourInterval(6, 7), hourInterval(9, 10)}},
+		{"difference", a.Difference(b), []Interval{hourInterval(0, 2), hourInterval(7, 9)}},
+		{"complement", a.Complement(hourInterval(-2, 12)), []Interval{hourInterval(-2, 0), hourInterval(4, 6), hourInterval(10, 12)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.Intervals(); !equalIntervals(got, tt.expected) {
+				t.Errorf("%s = %v, want %v", tt.name, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIntervalSetDuration(t *testing.T) {
+	s := NewIntervalSet(hourInterval(0, 4), hourInterval(2, 5), hourInterval(8, 9))
+	if got := s.Duration(); got != 6*time.Hour {
+		t.Errorf("Duration() = %v, want %v", got, 6*time.Hour)
+	}
+	if gaps := s.Gaps(hourInterval(0, 10)); !equalIntervals(gaps, []Interval{hourInterval(5, 8), hourInterval(9, 10)}) {
+		t.Errorf("Gaps() = %v", gaps)
+	}
+	if !s.Contains(hourInterval(3, 4).Start) || s.Contains(hourInterval(5, 6).Start) {
+		t.Errorf("Contains() returned unexpected result")
+	}
+}
+
+func TestMissingBiDays(t *testing.T) {
+	loc := getTestTimezone()
+	loaded := NewIntervalSet(
+		DayInterval("20240701", loc),
+		DayInterval("20240702", loc),
+		DayInterval("20240704", loc),
+		NewInterval(DayInterval("20240705", loc).Start, DayInterval("20240705", loc).Start.Add(time.Hour)),
+	)
+	expected := []string{"20240703", "20240705"}
+	if got := MissingBiDays(loaded, "20240701", "20240705", loc); !equalStringSlices(got, expected) {
+		t.Errorf("MissingBiDays() = %v, want %v", got, expected)
+	}
+}
@@ -0,0 +1,479 @@
+package timeutil
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// localeNames 语言区域的月份、星期、上下午名称
+type localeNames struct {
+	months        [12]string
+	shortMonths   [12]string
+	weekdays      [7]string
+	shortWeekdays [7]string
+	am, pm        string
+	eras          []string // {era}可能的取值，解析时使用
+}
+
+var localeNameTable = map[Locale]*localeNames{
+	LocaleZhCN: {
+		months:        [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		shortMonths:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdays:      [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		shortWeekdays: [7]string{"周日", "周一", "周二", "周三", "周四", "周五", "周六"},
+		am:            "上午",
+		pm:            "下午",
+		eras:          []string{"公元"},
+	},
+	LocaleZhTW: {
+		months:        [12]string{"一月", "二月", "三月", "四月", "五月", "六月", "七月", "八月", "九月", "十月", "十一月", "十二月"},
+		shortMonths:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdays:      [7]string{"星期日", "星期一", "星期二", "星期三", "星期四", "星期五", "星期六"},
+		shortWeekdays: [7]string{"週日", "週一", "週二", "週三", "週四", "週五", "週六"},
+		am:            "上午",
+		pm:            "下午",
+		eras:          []string{"民國", "民國前"},
+	},
+	LocaleJaJP: {
+		months:        [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		shortMonths:   [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdays:      [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		shortWeekdays: [7]string{"日", "月", "火", "水", "木", "金", "土"},
+		am:            "午前",
+		pm:            "午後",
+		eras:          []string{"令和", "平成", "昭和", "大正", "明治"},
+	},
+	LocaleEnUS: {
+		months:        [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		shortMonths:   [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		weekdays:      [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		shortWeekdays: [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+		am:            "AM",
+		pm:            "PM",
+		eras:          []string{"AD"},
+	},
+}
+
+// japaneseEras 日本的元号及开始日期，按时间倒序
+var japaneseEras = []struct {
+	name       string
+	year       int
+	month, day int
+}{
+	{"令和", 2019, 5, 1},
+	{"平成", 1989, 1, 8},
+	{"昭和", 1926, 12, 25},
+	{"大正", 1912, 7, 30},
+	{"明治", 1868, 1, 25},
+}
+
+// localeLayoutTokens FormatLocale、ParseLocale按语言区域处理的layout片段，较长的在前
+var localeLayoutTokens = []string{"January", "Jan", "Monday", "Mon", "PM", "pm", "{era}", "{eraYear}", "{cnYear}", "{cnMonth}", "{cnDay}"}
+
+const (
+	chineseDigits  = "〇一二三四五六七八九"
+	chineseNumeral = chineseDigits + "零十"
+)
+
+func localeNamesOf(locale Locale) *localeNames {
+	if names, ok := localeNameTable[locale]; ok {
+		return names
+	}
+	return localeNameTable[LocaleEnUS]
+}
+
+// MonthName 月份在语言区域中的名称，short为true时为简称，例如zh-CN的"一月"和"1月"，不支持的语言区域使用英文
+func MonthName(month time.Month, locale Locale, short bool) string {
+	names := localeNamesOf(locale)
+	if short {
+		return names.shortMonths[month-1]
+	}
+	return names.months[month-1]
+}
+
+// WeekdayName 星期在语言区域中的名称，short为true时为简称，例如zh-CN的"星期五"和"周五"、ja-JP的"金曜日"和"金"
+func WeekdayName(weekday time.Weekday, locale Locale, short bool) string {
+	names := localeNamesOf(locale)
+	if short {
+		return names.shortWeekdays[weekday]
+	}
+	return names.weekdays[weekday]
+}
+
+// FormatLocale 按语言区域格式化时间，layout与time.Format相同，其中的名称按语言区域输出：
+//   - January、Jan：月份全称、简称，例如一月、1月
+//   - Monday、Mon：星期全称、简称，例如星期五、周五，ja-JP为金曜日、金
+//   - PM、pm：上下午，例如上午、下午，ja-JP为午前、午後
+//
+// 此外支持以下扩展占位符：
+//   - {era}、{eraYear}：纪年及年份，ja-JP为元号(首年为"元")，zh-TW为民国纪年，zh-CN为公元，en-US为AD
+//   - {cnYear}、{cnMonth}、{cnDay}：中文数字的年(逐位，例如二〇二四)、月、日(例如十二、二十五)
+//
+// 例如"2006年1月2日 Monday"在zh-CN下输出"2024年1月5日 星期五"，"{era}{eraYear}年1月2日(Mon)"在ja-JP下输出"令和6年1月5日(金)"。
+// 不支持的语言区域使用英文
+func FormatLocale(t time.Time, layout string, locale Locale, timezone *time.Location) string {
+	t = t.In(timezone)
+	names := localeNamesOf(locale)
+	var b strings.Builder
+	for _, tok := range splitLocaleLayout(layout) {
+		if !tok.locale {
+			b.WriteString(t.Format(tok.text))
+			continue
+		}
+		switch tok.text {
+		case "January":
+			b.WriteString(names.months[t.Month()-1])
+		case "Jan":
+			b.WriteString(names.shortMonths[t.Month()-1])
+		case "Monday":
+			b.WriteString(names.weekdays[t.Weekday()])
+		case "Mon":
+			b.WriteString(names.shortWeekdays[t.Weekday()])
+		case "PM", "pm":
+			marker := names.am
+			if t.Hour() >= 12 {
+				marker = names.pm
+			}
+			if tok.text == "pm" {
+				marker = strings.ToLower(marker)
+			}
+			b.WriteString(marker)
+		case "{era}":
+			era, _ := eraOf(t, locale)
+			b.WriteString(era)
+		case "{eraYear}":
+			_, year := eraOf(t, locale)
+			if year == 1 && locale == LocaleJaJP {
+				b.WriteString("元")
+			} else {
+				b.WriteString(strconv.Itoa(year))
+			}
+		case "{cnYear}":
+			year := t.Year()
+			if year < 0 {
+				// 与"2006"一样用负号表示公元前的天文年份
+				b.WriteByte('-')
+				year = -year
+			}
+			for _, r := range strconv.Itoa(year) {
+				b.WriteString(chineseDigit(int(r - '0')))
+			}
+		case "{cnMonth}":
+			b.WriteString(formatChineseNumber(int(t.Month())))
+		case "{cnDay}":
+			b.WriteString(formatChineseNumber(t.Day()))
+		}
+	}
+	return b.String()
+}
+
+// ParseLocale 按语言区域解析FormatLocale格式化的时间，layout的写法与FormatLocale相同。
+// {eraYear}之前没有{era}时，zh-TW按民国纪年，其他语言区域按公元；月份、星期等名称必须与语言区域一致
+func ParseLocale(value, layout string, locale Locale, timezone *time.Location) (time.Time, error) {
+	names := localeNamesOf(locale)
+	tokens := splitLocaleLayout(layout)
+	errParse := fmt.Errorf("timeutil: cannot parse %q as %q in %s", value, layout, locale)
+
+	var goLayout, goValue strings.Builder
+	era := ""
+	if locale == LocaleZhTW {
+		era = "民國"
+	}
+	rest := value
+	for i, tok := range tokens {
+		if !tok.locale {
+			n, ok := matchLayoutSegment(tok.text, rest, i == len(tokens)-1)
+			if !ok {
+				return time.Time{}, errParse
+			}
+			goLayout.WriteString(tok.text)
+			goValue.WriteString(rest[:n])
+			rest = rest[n:]
+			continue
+		}
+
+		var matched string
+		switch tok.text {
+		case "January", "Jan", "Monday", "Mon":
+			candidates, english := names.months[:], localeNameTable[LocaleEnUS].months[:]
+			switch tok.text {
+			case "Jan":
+				candidates, english = names.shortMonths[:], localeNameTable[LocaleEnUS].shortMonths[:]
+			case "Monday":
+				candidates, english = names.weekdays[:], localeNameTable[LocaleEnUS].weekdays[:]
+			case "Mon":
+				candidates, english = names.shortWeekdays[:], localeNameTable[LocaleEnUS].shortWeekdays[:]
+			}
+			idx := matchName(rest, candidates)
+			if idx < 0 {
+				return time.Time{}, errParse
+			}
+			matched = candidates[idx]
+			goLayout.WriteString(tok.text)
+			goValue.WriteString(english[idx])
+		case "PM", "pm":
+			am, pm := names.am, names.pm
+			if tok.text == "pm" {
+				am, pm = strings.ToLower(am), strings.ToLower(pm)
+			}
+			idx := matchName(rest, []string{am, pm})
+			if idx < 0 {
+				return time.Time{}, errParse
+			}
+			matched = []string{am, pm}[idx]
+			marker := []string{"AM", "PM"}[idx]
+			if tok.text == "pm" {
+				marker = strings.ToLower(marker)
+			}
+			goLayout.WriteString(tok.text)
+			goValue.WriteString(marker)
+		case "{era}":
+			idx := matchName(rest, names.eras)
+			if idx < 0 {
+				return time.Time{}, errParse
+			}
+			matched, era = names.eras[idx], names.eras[idx]
+		case "{eraYear}":
+			n, size, ok := parseLocaleNumber(rest, locale == LocaleJaJP)
+			if !ok {
+				return time.Time{}, errParse
+			}
+			year, ok := eraToYear(era, n)
+			if !ok {
+				return time.Time{}, errParse
+			}
+			matched = rest[:size]
+			goLayout.WriteString("2006")
+			goValue.WriteString(fmt.Sprintf("%04d", year))
+		case "{cnYear}":
+			size := prefixLen(rest, chineseDigits+"零")
+			if size == 0 {
+				return time.Time{}, errParse
+			}
+			matched = rest[:size]
+			year, _ := parseChineseNumber(matched)
+			goLayout.WriteString("2006")
+			goValue.WriteString(fmt.Sprintf("%04d", year))
+		case "{cnMonth}", "{cnDay}":
+			size := prefixLen(rest, chineseNumeral)
+			n, ok := parseChineseNumber(rest[:size])
+			if size == 0 || !ok {
+				return time.Time{}, errParse
+			}
+			matched = rest[:size]
+			if tok.text == "{cnMonth}" {
+				goLayout.WriteString("1")
+			} else {
+				goLayout.WriteString("2")
+			}
+			goValue.WriteString(strconv.Itoa(n))
+		}
+		rest = rest[len(matched):]
+	}
+	if rest != "" {
+		return time.Time{}, errParse
+	}
+	t, err := time.ParseInLocation(goLayout.String(), goValue.String(), timezone)
+	if err != nil {
+		return time.Time{}, errParse
+	}
+	return t, nil
+}
+
+type localeLayoutToken struct {
+	text   string
+	locale bool // 是否为按语言区域处理的片段
+}
+
+// splitLocaleLayout 将layout拆分为time.Format可以处理的片段和按语言区域处理的片段
+func splitLocaleLayout(layout string) []localeLayoutToken {
+	var tokens []localeLayoutToken
+	start := 0
+	for i := 0; i < len(layout); {
+		matched := ""
+		for _, tok := range localeLayoutTokens {
+			if strings.HasPrefix(layout[i:], tok) {
+				matched = tok
+				break
+			}
+		}
+		if matched == "" {
+			i++
+			continue
+		}
+		if start < i {
+			tokens = append(tokens, localeLayoutToken{text: layout[start:i]})
+		}
+		tokens = append(tokens, localeLayoutToken{text: matched, locale: true})
+		i += len(matched)
+		start = i
+	}
+	if start < len(layout) {
+		tokens = append(tokens, localeLayoutToken{text: layout[start:]})
+	}
+	return tokens
+}
+
+// matchLayoutSegment value开头能被layout片段解析的最长前缀的长度，last为true时必须解析整个value
+func matchLayoutSegment(segment, value string, last bool) (int, bool) {
+	if last {
+		_, err := time.Parse(segment, value)
+		return len(value), err == nil
+	}
+	for n := len(value); n >= 0; n-- {
+		if n < len(value) && !utf8.RuneStart(value[n]) {
+			continue
+		}
+		if _, err := time.Parse(segment, value[:n]); err == nil {
+			return n, true
+		}
+	}
+	return 0, false
+}
+
+// matchName value开头匹配的最长名称的下标，没有匹配时返回-1
+func matchName(value string, names []string) int {
+	order := make([]int, len(names))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return len(names[order[i]]) > len(names[order[j]]) })
+	for _, i := range order {
+		if names[i] != "" && strings.HasPrefix(value, names[i]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// eraOf t在语言区域中的纪年名称和年份
+func eraOf(t time.Time, locale Locale) (string, int) {
+	y, m, d := t.Date()
+	switch locale {
+	case LocaleJaJP:
+		for _, era := range japaneseEras {
+			if civilDays(y, m, d) >= civilDays(era.year, time.Month(era.month), era.day) {
+				return era.name, y - era.year + 1
+			}
+		}
+		return "", y
+	case LocaleZhTW:
+		if y >= 1912 {
+			return "民國", y - 1911
+		}
+		return "民國前", 1912 - y
+	}
+	return localeNamesOf(locale).eras[0], y
+}
+
+// eraToYear 纪年的年份转换为公元年份，era为空时即为公元年份
+func eraToYear(era string, n int) (int, bool) {
+	if n < 1 {
+		return 0, false
+	}
+	switch era {
+	case "民國":
+		return n + 1911, true
+	case "民國前":
+		return 1912 - n, true
+	}
+	for _, e := range japaneseEras {
+		if e.name == era {
+			return e.year + n - 1, true
+		}
+	}
+	return n, true
+}
+
+// parseLocaleNumber 解析value开头的阿拉伯数字或中文数字，yuan为true时"元"表示1
+func parseLocaleNumber(value string, yuan bool) (n, size int, ok bool) {
+	if yuan && strings.HasPrefix(value, "元") {
+		return 1, len("元"), true
+	}
+	if size = prefixLen(value, "0123456789"); size > 0 {
+		n, err := strconv.Atoi(value[:size])
+		return n, size, err == nil
+	}
+	size = prefixLen(value, chineseNumeral)
+	n, ok = parseChineseNumber(value[:size])
+	return n, size, size > 0 && ok
+}
+
+// prefixLen value开头由chars中的字符组成的部分的字节数
+func prefixLen(value, chars string) int {
+	for i, r := range value {
+		if !strings.ContainsRune(chars, r) {
+			return i
+		}
+	}
+	return len(value)
+}
+
+func chineseDigit(n int) string {
+	return []string{"〇", "一", "二", "三", "四", "五", "六", "七", "八", "九"}[n]
+}
+
+// formatChineseNumber 1至99的中文数字，例如十、十五、二十五
+func formatChineseNumber(n int) string {
+	switch {
+	case n < 10:
+		return chineseDigit(n)
+	case n < 20:
+		return "十" + strings.TrimPrefix(chineseDigit(n%10), "〇")
+	}
+	s := chineseDigit(n/10) + "十"
+	if n%10 != 0 {
+		s += chineseDigit(n % 10)
+	}
+	return s
+}
+
+// parseChineseNumber 解析中文数字，支持逐位书写(二〇二四)和带"十"的两位数(十五、二十五)
+func parseChineseNumber(s string) (int, bool) {
+	digit := func(r rune) (int, bool) {
+		if r == '零' {
+			return 0, true
+		}
+		i := strings.IndexRune(chineseDigits, r)
+		if i < 0 {
+			return 0, false
+		}
+		return utf8.RuneCountInString(chineseDigits[:i]), true
+	}
+	if s == "" {
+		return 0, false
+	}
+	if tens, ones, ok := strings.Cut(s, "十"); ok {
+		t, o := 1, 0
+		if tens != "" {
+			runes := []rune(tens)
+			d, ok := digit(runes[0])
+			if len(runes) != 1 || !ok || d == 0 {
+				return 0, false
+			}
+			t = d
+		}
+		if ones != "" {
+			runes := []rune(ones)
+			d, ok := digit(runes[0])
+			if len(runes) != 1 || !ok || d == 0 {
+				return 0, false
+			}
+			o = d
+		}
+		return t*10 + o, true
+	}
+	n := 0
+	for _, r := range s {
+		d, ok := digit(r)
+		if !ok {
+			return 0, false
+		}
+		n = n*10 + d
+	}
+	return n, true
+}
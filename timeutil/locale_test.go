@@ -0,0 +1,108 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatLocale(t *testing.T) {
+	loc := getTestTimezone()
+	tm := time.Date(2024, 1, 5, 15, 4, 5, 0, loc)
+	tests := []struct {
+		layout   string
+		locale   Locale
+		expected string
+	}{
+		{"2006年1月2日 Monday", LocaleZhCN, "2024年1月5日 星期五"},
+		{"2006年1月2日 Mon PM3:04", LocaleZhCN, "2024年1月5日 周五 下午3:04"},
+		{"January Jan", LocaleZhCN, "一月 1月"},
+		{"{cnYear}年{cnMonth}月{cnDay}日", LocaleZhCN, "二〇二四年一月五日"},
+		{"{era}{eraYear}年1月2日(Mon)", LocaleJaJP, "令和6年1月5日(金)"},
+		{"Monday PM3時04分", LocaleJaJP, "金曜日 午後3時04分"},
+		{"{era}{eraYear}年1月2日 Mon", LocaleZhTW, "民國113年1月5日 週五"},
+		{"Monday, January 2, 2006 3:04 PM", LocaleEnUS, "Friday, January 5, 2024 3:04 PM"},
+		{"Mon Jan 2 3:04pm", Locale("fr-FR"), "Fri Jan 5 3:04pm"},
+		{"{era} 2006", LocaleEnUS, "AD 2024"},
+	}
+	for _, test := range tests {
+		if got := FormatLocale(tm, test.layout, test.locale, loc); got != test.expected {
+			t.Errorf("FormatLocale(%q, %s) = %q; want %q", test.layout, test.locale, got, test.expected)
+		}
+	}
+
+	eras := []struct {
+		day      time.Time
+		expected string
+	}{
+		{time.Date(2019, 4, 30, 0, 0, 0, 0, loc), "平成31年"},
+		{time.Date(2019, 5, 1, 0, 0, 0, 0, loc), "令和元年"},
+		{time.Date(1989, 1, 7, 0, 0, 0, 0, loc), "昭和64年"},
+		{time.Date(1989, 1, 8, 0, 0, 0, 0, loc), "平成元年"},
+	}
+	for _, test := range eras {
+		if got := FormatLocale(test.day, "{era}{eraYear}年", LocaleJaJP, loc); got != test.expected {
+			t.Errorf("FormatLocale(%v) = %q; want %q", test.day, got, test.expected)
+		}
+	}
+	if got := FormatLocale(time.Date(-205, 1, 5, 0, 0, 0, 0, loc), "{cnYear}年", LocaleZhCN, loc); got != "-二〇五年" {
+		t.Errorf("FormatLocale(negative year) = %q", got)
+	}
+	if got := FormatLocale(time.Date(2024, 12, 25, 9, 0, 0, 0, loc), "{cnMonth}月{cnDay}日 PM", LocaleZhCN, loc); got != "十二月二十五日 上午" {
+		t.Errorf("FormatLocale(cn numerals) = %q", got)
+	}
+	if got := FormatLocale(time.Date(1911, 10, 10, 0, 0, 0, 0, loc), "{era}{eraYear}年", LocaleZhTW, loc); got != "民國前1年" {
+		t.Errorf("FormatLocale(民國前) = %q", got)
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	loc := getTestTimezone()
+	tests := []struct {
+		value    string
+		layout   string
+		locale   Locale
+		expected time.Time
+	}{
+		{"2024年1月5日 星期五", "2006年1月2日 Monday", LocaleZhCN, time.Date(2024, 1, 5, 0, 0, 0, 0, loc)},
+		{"2024年11月15日 下午3:04", "2006年Jan2日 PM3:04", LocaleZhCN, time.Date(2024, 11, 15, 15, 4, 0, 0, loc)},
+		{"二〇二四年十二月二十五日", "{cnYear}年{cnMonth}月{cnDay}日", LocaleZhCN, time.Date(2024, 12, 25, 0, 0, 0, 0, loc)},
+		{"令和6年1月5日(金)", "{era}{eraYear}年1月2日(Mon)", LocaleJaJP, time.Date(2024, 1, 5, 0, 0, 0, 0, loc)},
+		{"令和元年5月1日", "{era}{eraYear}年1月2日", LocaleJaJP, time.Date(2019, 5, 1, 0, 0, 0, 0, loc)},
+		{"平成三十一年四月三十日", "{era}{eraYear}年{cnMonth}月{cnDay}日", LocaleJaJP, time.Date(2019, 4, 30, 0, 0, 0, 0, loc)},
+		{"113年1月5日 午前9:30", "{eraYear}年1月2日 PM3:04", LocaleZhTW, time.Time{}},
+		{"113年1月5日 上午9:30", "{eraYear}年1月2日 PM3:04", LocaleZhTW, time.Date(2024, 1, 5, 9, 30, 0, 0, loc)},
+		{"Friday, January 5, 2024 3:04 pm", "Monday, January 2, 2006 3:04 pm", LocaleEnUS, time.Date(2024, 1, 5, 15, 4, 0, 0, loc)},
+		{"2024年13月5日", "2006年1月2日", LocaleZhCN, time.Time{}},
+		{"2024年1月5日 星期八", "2006年1月2日 Monday", LocaleZhCN, time.Time{}},
+		{"2024年1月5日 星期五!", "2006年1月2日 Monday", LocaleZhCN, time.Time{}},
+	}
+	for _, test := range tests {
+		got, err := ParseLocale(test.value, test.layout, test.locale, loc)
+		if test.expected.IsZero() {
+			if err == nil {
+				t.Errorf("ParseLocale(%q) = %v; want error", test.value, got)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(test.expected) {
+			t.Errorf("ParseLocale(%q, %q, %s) = %v, %v; want %v", test.value, test.layout, test.locale, got, err, test.expected)
+		}
+	}
+
+	// 格式化后能解析回原时间
+	tm := time.Date(2025, 10, 1, 21, 45, 0, 0, loc)
+	for locale, layout := range map[Locale]string{
+		LocaleZhCN: "{cnYear}年January{cnDay}日 Monday PM3点04分",
+		LocaleZhTW: "{era}{eraYear}年1月2日 Mon PM3:04",
+		LocaleJaJP: "{era}{eraYear}年Jan2日(Mon) PM3時04分",
+		LocaleEnUS: "Mon, 02 Jan 2006 3:04 PM",
+	} {
+		s := FormatLocale(tm, layout, locale, loc)
+		if got, err := ParseLocale(s, layout, locale, loc); err != nil || !got.Equal(tm) {
+			t.Errorf("ParseLocale(FormatLocale(%s) = %q) = %v, %v; want %v", locale, s, got, err, tm)
+		}
+	}
+	if MonthName(time.March, LocaleZhTW, false) != "三月" || WeekdayName(time.Sunday, LocaleJaJP, false) != "日曜日" {
+		t.Errorf("MonthName/WeekdayName mismatch")
+	}
+}
@@ -0,0 +1,128 @@
+package timeutil
+
+import (
+	"time"
+)
+
+// lunarInfo 1900至2049年的农历数据，每年一个值：
+// bit0-3为闰月月份(0表示无闰月)，bit4-15依次为12至1月的大小(1为30天，0为29天)，bit16为闰月的大小
+var lunarInfo = [...]int{
+	0x04bd8, 0x04ae0, 0x0a570, 0x054d5, 0x0d260, 0x0d950, 0x16554, 0x056a0, 0x09ad0, 0x055d2, // 1900-1909
+	0x04ae0, 0x0a5b6, 0x0a4d0, 0x0d250, 0x1d255, 0x0b540, 0x0d6a0, 0x0ada2, 0x095b0, 0x14977, // 1910-1919
+	0x04970, 0x0a4b0, 0x0b4b5, 0x06a50, 0x06d40, 0x1ab54, 0x02b60, 0x09570, 0x052f2, 0x04970, // 1920-1929
+	0x06566, 0x0d4a0, 0x0ea50, 0x16a95, 0x05ad0, 0x02b60, 0x186e3, 0x092e0, 0x1c8d7, 0x0c950, // 1930-1939
+	0x0d4a0, 0x1d8a6, 0x0b550, 0x056a0, 0x1a5b4, 0x025d0, 0x092d0, 0x0d2b2, 0x0a950, 0x0b557, // 1940-1949
+	0x06ca0, 0x0b550, 0x15355, 0x04da0, 0x0a5b0, 0x14573, 0x052b0, 0x0a9a8, 0x0e950, 0x06aa0, // 1950-1959
+	0x0aea6, 0x0ab50, 0x04b60, 0x0aae4, 0x0a570, 0x05260, 0x0f263, 0x0d950, 0x05b57, 0x056a0, // 1960-1969
+	0x096d0, 0x04dd5, 0x04ad0, 0x0a4d0, 0x0d4d4, 0x0d250, 0x0d558, 0x0b540, 0x0b6a0, 0x195a6, // 1970-1979
+	0x095b0, 0x049b0, 0x0a974, 0x0a4b0, 0x0b27a, 0x06a50, 0x06d40, 0x0af46, 0x0ab60, 0x09570, // 1980-1989
+	0x04af5, 0x04970, 0x064b0, 0x074a3, 0x0ea50, 0x06b58, 0x05ac0, 0x0ab60, 0x096d5, 0x092e0, // 1990-1999
+	0x0c960, 0x0d954, 0x0d4a0, 0x0da50, 0x07552, 0x056a0, 0x0abb7, 0x025d0, 0x092d0, 0x0cab5, // 2000-2009
+	0x0a950, 0x0b4a0, 0x0baa4, 0x0ad50, 0x055d9, 0x04ba0, 0x0a5b0, 0x15176, 0x052b0, 0x0a930, // 2010-2019
+	0x07954, 0x06aa0, 0x0ad50, 0x05b52, 0x04b60, 0x0a6e6, 0x0a4e0, 0x0d260, 0x0ea65, 0x0d530, // 2020-2029
+	0x05aa0, 0x076a3, 0x096d0, 0x04afb, 0x04ad0, 0x0a4d0, 0x1d0b6, 0x0d250, 0x0d520, 0x0dd45, // 2030-2039
+	0x0b5a0, 0x056d0, 0x055b2, 0x049b0, 0x0a577, 0x0a4b0, 0x0aa50, 0x1b255, 0x06d20, 0x0ada0, // 2040-2049
+}
+
+const (
+	lunarMinYear = 1900
+	lunarMaxYear = lunarMinYear + len(lunarInfo) - 1
+)
+
+// lunarBase 农历1900年正月初一对应的公历日期
+var lunarBase = civilDays(1900, time.January, 31)
+
+// lunarLeapMonth 农历year年的闰月月份，无闰月时为0
+func lunarLeapMonth(year int) int {
+	return lunarInfo[year-lunarMinYear] & 0xf
+}
+
+// lunarMonthDays 农历year年month月的天数，leap为true时为闰月
+func lunarMonthDays(year, month int, leap bool) int {
+	info := lunarInfo[year-lunarMinYear]
+	if leap {
+		if info&0x10000 != 0 {
+			return 30
+		}
+		return 29
+	}
+	if info&(0x10000>>uint(month)) != 0 {
+		return 30
+	}
+	return 29
+}
+
+// lunarYearDays 农历year年的天数
+func lunarYearDays(year int) int {
+	days := 0
+	for m := 1; m <= 12; m++ {
+		days += lunarMonthDays(year, m, false)
+	}
+	if lunarLeapMonth(year) > 0 {
+		days += lunarMonthDays(year, lunarLeapMonth(year), true)
+	}
+	return days
+}
+
+// LunarToSolar 农历日期转换为公历日期(UTC零点表示的自然日)，leap为true时表示闰月。
+// 支持农历1900至2049年，日期不存在(例如小月三十、该年没有对应闰月)时返回false
+func LunarToSolar(year, month, day int, leap bool) (time.Time, bool) {
+	if year < lunarMinYear || year > lunarMaxYear || month < 1 || month > 12 || day < 1 {
+		return time.Time{}, false
+	}
+	if leap && lunarLeapMonth(year) != month {
+		return time.Time{}, false
+	}
+	if day > lunarMonthDays(year, month, leap) {
+		return time.Time{}, false
+	}
+	offset := 0
+	for y := lunarMinYear; y < year; y++ {
+		offset += lunarYearDays(y)
+	}
+	for m := 1; m < month; m++ {
+		offset += lunarMonthDays(year, m, false)
+		if lunarLeapMonth(year) == m {
+			offset += lunarMonthDays(year, m, true)
+		}
+	}
+	if leap {
+		offset += lunarMonthDays(year, month, false)
+	}
+	return time.Unix(int64(lunarBase+offset+day-1)*86400, 0).UTC(), true
+}
+
+// SolarToLunar t所在自然日对应的农历日期，leap为true时表示闰月；超出农历1900至2049年时ok为false
+func SolarToLunar(t time.Time) (year, month, day int, leap, ok bool) {
+	y, m, d := t.Date()
+	offset := civilDays(y, m, d) - lunarBase
+	if offset < 0 {
+		return 0, 0, 0, false, false
+	}
+	for year = lunarMinYear; year <= lunarMaxYear; year++ {
+		days := lunarYearDays(year)
+		if offset < days {
+			break
+		}
+		offset -= days
+	}
+	if year > lunarMaxYear {
+		return 0, 0, 0, false, false
+	}
+	leapMonth := lunarLeapMonth(year)
+	for month = 1; month <= 12; month++ {
+		days := lunarMonthDays(year, month, false)
+		if offset < days {
+			return year, month, offset + 1, false, true
+		}
+		offset -= days
+		if month == leapMonth {
+			days = lunarMonthDays(year, month, true)
+			if offset < days {
+				return year, month, offset + 1, true, true
+			}
+			offset -= days
+		}
+	}
+	return 0, 0, 0, false, false
+}
@@ -0,0 +1,48 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLunarToSolar(t *testing.T) {
+	tests := []struct {
+		year, month, day int
+		leap             bool
+		expected         string
+	}{
+		{1900, 1, 1, false, "19000131"},
+		{2000, 1, 1, false, "20000205"},
+		{2020, 1, 1, false, "20200125"},
+		{2020, 4, 1, true, "20200523"}, // 闰四月
+		{2020, 5, 5, false, "20200625"},
+		{2024, 1, 1, false, "20240210"},
+		{2024, 8, 15, false, "20240917"},
+		{2025, 5, 5, false, "20250531"},
+		{2025, 8, 15, false, "20251006"},
+		{2049, 1, 1, false, "20490202"},
+	}
+	for _, test := range tests {
+		got, ok := LunarToSolar(test.year, test.month, test.day, test.leap)
+		if !ok || got.Format(FormatYYYYMMDDNoSymbol) != test.expected {
+			t.Errorf("LunarToSolar(%d, %d, %d, %v) = %v, %v; want %s", test.year, test.month, test.day, test.leap, got, ok, test.expected)
+			continue
+		}
+		y, m, d, leap, ok := SolarToLunar(got)
+		if !ok || y != test.year || m != test.month || d != test.day || leap != test.leap {
+			t.Errorf("SolarToLunar(%s) = %d-%d-%d %v %v; want %d-%d-%d %v", test.expected, y, m, d, leap, ok, test.year, test.month, test.day, test.leap)
+		}
+	}
+
+	for _, bad := range [][3]int{{1899, 1, 1}, {2050, 1, 1}, {2024, 13, 1}, {2024, 1, 0}, {2024, 1, 30}} {
+		if _, ok := LunarToSolar(bad[0], bad[1], bad[2], false); ok {
+			t.Errorf("LunarToSolar(%v) ok = true; want false", bad)
+		}
+	}
+	if _, ok := LunarToSolar(2024, 4, 1, true); ok {
+		t.Errorf("LunarToSolar(2024 leap 4) ok = true; want false")
+	}
+	if _, _, _, _, ok := SolarToLunar(time.Date(1900, 1, 30, 0, 0, 0, 0, time.UTC)); ok {
+		t.Errorf("SolarToLunar(1900-01-30) ok = true; want false")
+	}
+}
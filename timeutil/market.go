@@ -0,0 +1,286 @@
+package timeutil
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxMarketScanDays NextOpen、PreviousClose向前后查找交易时段的最大天数
+const maxMarketScanDays = 366
+
+// MarketConfig 交易日历配置
+type MarketConfig struct {
+	Name     string         // 市场名称
+	Timezone *time.Location // 交易时段所在的时区
+	Sessions []DayPeriod    // 每个交易日的交易时段，按时间顺序
+	Holidays *Calendar      // 休市日历，IsHoliday为true的日期休市；周六、周日总是休市，调休上班日也不开市
+	// Special 特殊交易日的交易时段，例如提前收市或交易时间调整，day为当地零点，ok为false时使用Sessions
+	Special func(day time.Time) (sessions []DayPeriod, ok bool)
+}
+
+// MarketCalendar 交易所的交易日历，按交易日和交易时段回答是否开市、下一次开市等问题。创建后不可修改，并发安全
+type MarketCalendar struct {
+	config MarketConfig
+}
+
+// SessionBucket GroupBySession的结果，同一交易时段内的时间点
+type SessionBucket struct {
+	Session Interval
+	Times   []time.Time
+}
+
+// NewMarketCalendar 创建交易日历，Timezone为空时为UTC
+func NewMarketCalendar(config MarketConfig) *MarketCalendar {
+	if config.Timezone == nil {
+		config.Timezone = TimezoneUtc
+	}
+	return &MarketCalendar{config: config}
+}
+
+// Name 市场名称
+func (m *MarketCalendar) Name() string {
+	return m.config.Name
+}
+
+// Timezone 交易时段所在的时区
+func (m *MarketCalendar) Timezone() *time.Location {
+	return m.config.Timezone
+}
+
+// Covers 休市日历是否收录了year年，未收录的年份只有周末休市，IsTradingDay等结果不可靠；没有休市日历时总是返回true
+func (m *MarketCalendar) Covers(year int) bool {
+	return m.config.Holidays == nil || m.config.Holidays.Covers(year)
+}
+
+// IsTradingDay t在市场时区下所在日是否为交易日
+func (m *MarketCalendar) IsTradingDay(t time.Time) bool {
+	if IsWeekend(t, m.config.Timezone) {
+		return false
+	}
+	return m.config.Holidays == nil || !m.config.Holidays.IsHoliday(t, m.config.Timezone)
+}
+
+// Sessions t在市场时区下所在日的交易时段，非交易日返回nil
+func (m *MarketCalendar) Sessions(t time.Time) []Interval {
+	if !m.IsTradingDay(t) {
+		return nil
+	}
+	day := StartOfDay(t, m.config.Timezone)
+	periods := m.config.Sessions
+	if m.config.Special != nil {
+		if special, ok := m.config.Special(day); ok {
+			periods = special
+		}
+	}
+	ret := make([]Interval, 0, len(periods))
+	for _, p := range periods {
+		ret = append(ret, p.On(day, m.config.Timezone))
+	}
+	return ret
+}
+
+// SessionOf t所在的交易时段，不在交易时间内时ok为false
+func (m *MarketCalendar) SessionOf(t time.Time) (session Interval, ok bool) {
+	for _, s := range m.Sessions(t) {
+		if s.Contains(t) {
+			return s, true
+		}
+	}
+	return Interval{}, false
+}
+
+// IsTradingTime t是否在交易时间内，交易时段左闭右开，例如A股11:30:00已休市
+func (m *MarketCalendar) IsTradingTime(t time.Time) bool {
+	_, ok := m.SessionOf(t)
+	return ok
+}
+
+// NextOpen t之后(不含t)的第一个开市时刻，包括午间休市后的开市；一年内没有交易时段时返回零值
+func (m *MarketCalendar) NextOpen(t time.Time) time.Time {
+	day := StartOfDay(t, m.config.Timezone)
+	for i := 0; i <= maxMarketScanDays; i++ {
+		for _, s := range m.Sessions(day.AddDate(0, 0, i)) {
+			if s.Start.After(t) {
+				return s.Start
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// PreviousClose t及之前最近的一个收市时刻，包括午间休市；一年内没有交易时段时返回零值
+func (m *MarketCalendar) PreviousClose(t time.Time) time.Time {
+	day := StartOfDay(t, m.config.Timezone)
+	for i := 0; i <= maxMarketScanDays; i++ {
+		sessions := m.Sessions(day.AddDate(0, 0, -i))
+		for j := len(sessions) - 1; j >= 0; j-- {
+			if !sessions[j].End.After(t) {
+				return sessions[j].End
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// TradingDuration [from, to)内的交易时长，from晚于to时返回0
+func (m *MarketCalendar) TradingDuration(from, to time.Time) time.Duration {
+	if !from.Before(to) {
+		return 0
+	}
+	span := Interval{Start: from, End: to}
+	var total time.Duration
+	for day := StartOfDay(from, m.config.Timezone); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, s := range m.Sessions(day) {
+			total += s.Intersect(span).Duration()
+		}
+	}
+	return total
+}
+
+// TradingMinutes [from, to)内的交易分钟数，不足一分钟的部分舍去
+func (m *MarketCalendar) TradingMinutes(from, to time.Time) int {
+	return int(m.TradingDuration(from, to) / time.Minute)
+}
+
+// GroupBySession 将时间点按所在的交易时段分组，按时段先后排序，不在交易时间内的时间点丢弃
+func (m *MarketCalendar) GroupBySession(times []time.Time) []SessionBucket {
+	index := make(map[int64]int)
+	var buckets []SessionBucket
+	for _, t := range times {
+		s, ok := m.SessionOf(t)
+		if !ok {
+			continue
+		}
+		key := s.Start.UnixNano()
+		i, exists := index[key]
+		if !exists {
+			i = len(buckets)
+			index[key] = i
+			buckets = append(buckets, SessionBucket{Session: s})
+		}
+		buckets[i].Times = append(buckets[i].Times, t)
+	}
+	sort.SliceStable(buckets, func(i, j int) bool { return buckets[i].Session.Start.Before(buckets[j].Session.Start) })
+	return buckets
+}
+
+// marketFromYear、marketToYear 按规则生成休市日的年份范围
+const (
+	marketFromYear = 2000
+	marketToYear   = 2099
+)
+
+var chinaStockSessions = MustParseDayPeriods("09:30-11:30,13:00-15:00")
+
+var sseCalendar = sync.OnceValue(func() *MarketCalendar {
+	return NewMarketCalendar(MarketConfig{Name: "SSE", Timezone: TimezoneShanghai, Sessions: chinaStockSessions, Holidays: ChinaCalendar()})
+})
+
+var szseCalendar = sync.OnceValue(func() *MarketCalendar {
+	return NewMarketCalendar(MarketConfig{Name: "SZSE", Timezone: TimezoneShanghai, Sessions: chinaStockSessions, Holidays: ChinaCalendar()})
+})
+
+// SSECalendar 上海证券交易所交易日历，连续竞价时段09:30-11:30、13:00-15:00，休市日按ChinaCalendar的放假安排，
+// 周末的调休上班日不开市；ChinaCalendar未收录的年份Covers返回false
+func SSECalendar() *MarketCalendar {
+	return sseCalendar()
+}
+
+// SZSECalendar 深圳证券交易所交易日历，交易时段和休市日与SSECalendar相同
+func SZSECalendar() *MarketCalendar {
+	return szseCalendar()
+}
+
+var tseCalendar = sync.OnceValue(func() *MarketCalendar {
+	yearEnd := HolidayRuleSet{Name: "TSE", Rules: mustParseHolidayRules(tseHolidayRules)}
+	holidays := JapanCalendar(japanHolidayRulesFromYear, marketToYear).Overlay(yearEnd.Calendar(japanHolidayRulesFromYear, marketToYear))
+	extended := time.Date(2024, 11, 5, 0, 0, 0, 0, TimezoneJp)
+	before := MustParseDayPeriods("09:00-11:30,12:30-15:00")
+	return NewMarketCalendar(MarketConfig{
+		Name:     "TSE",
+		Timezone: TimezoneJp,
+		Sessions: MustParseDayPeriods("09:00-11:30,12:30-15:30"),
+		Holidays: holidays,
+		Special: func(day time.Time) ([]DayPeriod, bool) {
+			return before, day.Before(extended)
+		},
+	})
+})
+
+// TSECalendar 东京证券交易所交易日历，交易时段09:00-11:30、12:30-15:30(2024年11月5日之前为15:00收市)，
+// 休市日为日本国民祝日及12月31日至1月3日。休市日与JapanHolidayRules一样从2019年开始收录，更早的年份Covers返回false
+func TSECalendar() *MarketCalendar {
+	return tseCalendar()
+}
+
+const tseHolidayRules = `
+大納会翌日休業 = 12-31
+年始休業 = 01-02 days=2
+`
+
+var nyseCalendar = sync.OnceValue(func() *MarketCalendar {
+	rules := HolidayRuleSet{Name: "NYSE", Rules: mustParseHolidayRules(nyseHolidayRules)}
+	var holidays []Holiday
+	for y := marketFromYear; y <= marketToYear; y++ {
+		for _, h := range rules.Holidays(y) {
+			// 元旦为周六时不在前一年的12月31日休市
+			if h.Date.Year() == y {
+				holidays = append(holidays, h)
+			}
+		}
+	}
+	closures, err := ParseHolidayData(strings.NewReader(nyseSpecialClosures))
+	if err != nil {
+		panic(err)
+	}
+	calendar := NewCalendar("NYSE", append(holidays, closures...))
+	earlyClose := MustParseDayPeriods("09:30-13:00")
+	return NewMarketCalendar(MarketConfig{
+		Name:     "NYSE",
+		Timezone: TimezoneNy,
+		Sessions: MustParseDayPeriods("09:30-16:00"),
+		Holidays: calendar,
+		Special: func(day time.Time) ([]DayPeriod, bool) {
+			y, m, d := day.Date()
+			switch {
+			case m == time.July && d == 3, m == time.December && d == 24:
+				return earlyClose, true
+			case m == time.November:
+				thanksgiving, _ := NthWeekdayOfMonth(y, time.November, time.Thursday, 4, TimezoneNy)
+				return earlyClose, day.Equal(thanksgiving.AddDate(0, 0, 1))
+			}
+			return nil, false
+		},
+	})
+})
+
+// NYSECalendar 纽约证券交易所交易日历，交易时段为纽约时间09:30-16:00，
+// 7月3日、感恩节次日和12月24日为交易日时13:00提前收市
+func NYSECalendar() *MarketCalendar {
+	return nyseCalendar()
+}
+
+const nyseHolidayRules = `
+New Year's Day = 01-01 observed=nearest
+Martin Luther King Jr. Day = 3rd mon jan
+Washington's Birthday = 3rd mon feb
+Good Friday = easter-2
+Memorial Day = last mon may
+Juneteenth National Independence Day = 06-19 observed=nearest from=2022
+Independence Day = 07-04 observed=nearest
+Labor Day = 1st mon sep
+Thanksgiving Day = 4th thu nov
+Christmas Day = 12-25 observed=nearest
+`
+
+// nyseSpecialClosures 临时休市，格式同ParseHolidayData
+const nyseSpecialClosures = `
+2001-09-11~2001-09-14 holiday September 11 attacks
+2004-06-11 holiday National Day of Mourning for Ronald Reagan
+2007-01-02 holiday National Day of Mourning for Gerald Ford
+2012-10-29~2012-10-30 holiday Hurricane Sandy
+2018-12-05 holiday National Day of Mourning for George H. W. Bush
+2025-01-09 holiday National Day of Mourning for Jimmy Carter
+`
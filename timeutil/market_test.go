@@ -0,0 +1,141 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSSECalendar(t *testing.T) {
+	m := SSECalendar()
+	loc := TimezoneShanghai
+	at := func(day string, hour, minute int) time.Time {
+		d := Str2Time(day, FormatYYYYMMDDNoSymbol, loc)
+		return time.Date(d.Year(), d.Month(), d.Day(), hour, minute, 0, 0, loc)
+	}
+	tests := []struct {
+		t       time.Time
+		trading bool
+	}{
+		{at("20240701", 9, 29), false},
+		{at("20240701", 9, 30), true},
+		{at("20240701", 11, 30), false},
+		{at("20240701", 13, 0), true},
+		{at("20240701", 14, 59), true},
+		{at("20240701", 15, 0), false},
+		{at("20240204", 10, 0), false}, // 周日调休上班，不开市
+		{at("20241007", 10, 0), false}, // 国庆节
+		{at("20260216", 10, 0), false}, // 2026年春节
+		{at("20260302", 10, 0), true},
+		{time.Date(2024, 7, 1, 2, 0, 0, 0, TimezoneUtc), true},
+	}
+	for _, test := range tests {
+		if got := m.IsTradingTime(test.t); got != test.trading {
+			t.Errorf("IsTradingTime(%v) = %v; want %v", test.t, got, test.trading)
+		}
+	}
+
+	if got := m.NextOpen(at("20240701", 11, 30)); !got.Equal(at("20240701", 13, 0)) {
+		t.Errorf("NextOpen(lunch) = %v", got)
+	}
+	if got := m.NextOpen(at("20240930", 15, 0)); !got.Equal(at("20241008", 9, 30)) {
+		t.Errorf("NextOpen(before National Day) = %v", got)
+	}
+	if got := m.PreviousClose(at("20241008", 9, 0)); !got.Equal(at("20240930", 15, 0)) {
+		t.Errorf("PreviousClose(after National Day) = %v", got)
+	}
+	if got := m.PreviousClose(at("20240701", 11, 30)); !got.Equal(at("20240701", 11, 30)) {
+		t.Errorf("PreviousClose(11:30) = %v", got)
+	}
+	if got := m.TradingMinutes(at("20240628", 14, 0), at("20240701", 10, 0)); got != 90 {
+		t.Errorf("TradingMinutes() = %d; want 90", got)
+	}
+	if got := m.TradingDuration(at("20240701", 0, 0), at("20240708", 0, 0)); got != 20*time.Hour {
+		t.Errorf("TradingDuration(week) = %v; want 20h", got)
+	}
+	if SZSECalendar().Name() != "SZSE" || !SZSECalendar().IsTradingDay(at("20240701", 0, 0)) {
+		t.Errorf("SZSECalendar() should share SSE trading days")
+	}
+	if !m.Covers(2026) || m.Covers(2027) {
+		t.Errorf("Covers() should follow ChinaCalendar years")
+	}
+}
+
+func TestGroupBySession(t *testing.T) {
+	m := SSECalendar()
+	loc := TimezoneShanghai
+	times := []time.Time{
+		time.Date(2024, 7, 1, 13, 5, 0, 0, loc),
+		time.Date(2024, 7, 1, 9, 31, 0, 0, loc),
+		time.Date(2024, 7, 1, 12, 0, 0, 0, loc),
+		time.Date(2024, 7, 1, 10, 0, 0, 0, loc),
+	}
+	buckets := m.GroupBySession(times)
+	if len(buckets) != 2 || len(buckets[0].Times) != 2 || len(buckets[1].Times) != 1 {
+		t.Fatalf("GroupBySession() = %v", buckets)
+	}
+	if !buckets[0].Session.Start.Equal(time.Date(2024, 7, 1, 9, 30, 0, 0, loc)) || !buckets[1].Session.End.Equal(time.Date(2024, 7, 1, 15, 0, 0, 0, loc)) {
+		t.Errorf("GroupBySession() sessions = %v, %v", buckets[0].Session, buckets[1].Session)
+	}
+}
+
+func TestTSECalendar(t *testing.T) {
+	m := TSECalendar()
+	loc := TimezoneJp
+	if m.IsTradingDay(time.Date(2025, 1, 3, 10, 0, 0, 0, loc)) || m.IsTradingDay(time.Date(2024, 12, 31, 10, 0, 0, 0, loc)) {
+		t.Errorf("TSE should be closed for the new year holidays")
+	}
+	if m.IsTradingDay(time.Date(2024, 9, 23, 10, 0, 0, 0, loc)) {
+		t.Errorf("TSE should be closed on 振替休日 2024-09-23")
+	}
+	for _, day := range []string{"20190430", "20190501", "20190502", "20191022"} {
+		if m.IsTradingDay(Str2Time(day, FormatYYYYMMDDNoSymbol, loc)) {
+			t.Errorf("TSE should be closed on %s", day)
+		}
+	}
+	if !m.Covers(2019) || m.Covers(2018) || m.Covers(2002) {
+		t.Errorf("TSE holidays should be covered from 2019")
+	}
+	if !m.IsTradingTime(time.Date(2024, 11, 5, 15, 15, 0, 0, loc)) || m.IsTradingTime(time.Date(2024, 11, 1, 15, 15, 0, 0, loc)) {
+		t.Errorf("TSE should close at 15:30 from 2024-11-05 and 15:00 before")
+	}
+	if got := m.TradingMinutes(time.Date(2025, 1, 6, 0, 0, 0, 0, loc), time.Date(2025, 1, 7, 0, 0, 0, 0, loc)); got != 330 {
+		t.Errorf("TradingMinutes(2025-01-06) = %d; want 330", got)
+	}
+}
+
+func TestNYSECalendar(t *testing.T) {
+	m := NYSECalendar()
+	loc := TimezoneNy
+	closed := []string{"20240101", "20240329", "20240619", "20241128", "20241225", "20250109", "20220117"}
+	for _, day := range closed {
+		if m.IsTradingDay(Str2Time(day, FormatYYYYMMDDNoSymbol, loc)) {
+			t.Errorf("IsTradingDay(%s) = true; want false", day)
+		}
+	}
+	// 2022-01-01为周六，2021-12-31照常交易；哥伦布日照常交易
+	for _, day := range []string{"20211231", "20241014", "20241111"} {
+		if !m.IsTradingDay(Str2Time(day, FormatYYYYMMDDNoSymbol, loc)) {
+			t.Errorf("IsTradingDay(%s) = false; want true", day)
+		}
+	}
+
+	for day, close := range map[string]int{"20240703": 13, "20241129": 13, "20241224": 13, "20241126": 16} {
+		sessions := m.Sessions(Str2Time(day, FormatYYYYMMDDNoSymbol, loc))
+		if len(sessions) != 1 || sessions[0].End.In(loc).Hour() != close {
+			t.Errorf("Sessions(%s) = %v; want close at %d:00", day, sessions, close)
+		}
+	}
+
+	// 上海时间周六凌晨为纽约周五交易时间
+	friday := time.Date(2024, 7, 13, 3, 0, 0, 0, TimezoneShanghai)
+	if !m.IsTradingTime(friday) {
+		t.Errorf("IsTradingTime(%v) = false; want true", friday)
+	}
+	if got := m.NextOpen(friday); !got.Equal(time.Date(2024, 7, 15, 9, 30, 0, 0, loc)) {
+		t.Errorf("NextOpen(%v) = %v", friday, got)
+	}
+	// 夏令时切换日仍按当地时间09:30开市
+	if got := m.NextOpen(time.Date(2024, 3, 9, 0, 0, 0, 0, loc)); got.In(loc).Hour() != 9 || got.In(loc).Minute() != 30 || got.In(loc).Day() != 11 {
+		t.Errorf("NextOpen(DST) = %v", got.In(loc))
+	}
+}
@@ -0,0 +1,242 @@
+package timeutil
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrUnrecognizedDateExpr 无法识别的日期表达式
+var ErrUnrecognizedDateExpr = errors.New("timeutil: unrecognized date expression")
+
+var (
+	zhRelativeDays = map[string]int{
+		"今天": 0, "今日": 0, "昨天": -1, "昨日": -1, "前天": -2, "大前天": -3,
+		"明天": 1, "明日": 1, "后天": 2, "大后天": 3,
+	}
+	zhWeekdays = map[string]time.Weekday{
+		"一": time.Monday, "二": time.Tuesday, "三": time.Wednesday, "四": time.Thursday,
+		"五": time.Friday, "六": time.Saturday, "日": time.Sunday, "天": time.Sunday,
+	}
+	zhOffsets = map[string]int{"": 0, "本": 0, "这": 0, "今": 0, "上": -1, "去": -1, "下": 1, "明": 1, "前": -2, "后": 2}
+	zhAgoRe   = regexp.MustCompile(`^([0-9]+|[零一二两三四五六七八九十]+)(天|日|周|星期|个?月|年)(前|后|以前|以后|之前|之后)$`)
+	zhWeekRe  = regexp.MustCompile(`^(本|这|上|下)?(周|星期|礼拜)([一二三四五六日天]|初|末)?$`)
+	zhMonthRe = regexp.MustCompile(`^(本|这|上|下)?(个)?月(初|末|底)?$`)
+	zhYearRe  = regexp.MustCompile(`^(今|本|去|明|前|后)年(初|末|底)?$`)
+
+	enRelativeDays = map[string]int{
+		"today": 0, "yesterday": -1, "tomorrow": 1,
+		"day before yesterday": -2, "the day before yesterday": -2,
+		"day after tomorrow": 2, "the day after tomorrow": 2,
+	}
+	enWeekdays = map[string]time.Weekday{
+		"monday": time.Monday, "mon": time.Monday, "tuesday": time.Tuesday, "tue": time.Tuesday,
+		"wednesday": time.Wednesday, "wed": time.Wednesday, "thursday": time.Thursday, "thu": time.Thursday,
+		"friday": time.Friday, "fri": time.Friday, "saturday": time.Saturday, "sat": time.Saturday,
+		"sunday": time.Sunday, "sun": time.Sunday,
+	}
+	enNumbers = map[string]int{
+		"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5,
+		"six": 6, "seven": 7, "eight": 8, "nine": 9, "ten": 10,
+	}
+	enOffsets       = map[string]int{"last": -1, "previous": -1, "this": 0, "current": 0, "next": 1}
+	enAgoRe         = regexp.MustCompile(`^([0-9]+|a|an|one|two|three|four|five|six|seven|eight|nine|ten) (day|week|month|year)s? (ago|later|after|from now)$`)
+	enInRe          = regexp.MustCompile(`^in ([0-9]+|a|an|one|two|three|four|five|six|seven|eight|nine|ten) (day|week|month|year)s?$`)
+	enPeriodRe      = regexp.MustCompile(`^(last|previous|this|current|next) (week|month|year)(?: (start|beginning|end))?$`)
+	enPeriodOfRe    = regexp.MustCompile(`^(start|beginning|end) of (?:the )?(last|previous|this|current|next) (week|month|year)$`)
+	enWeekdayRe     = regexp.MustCompile(`^(?:(last|previous|this|next) )?([a-z]+)$`)
+	enWhitespaceRep = regexp.MustCompile(`\s+`)
+)
+
+// ParseDateExpr 将"昨天"、"上周一"、"本月初"、"last friday"、"3 days ago"、"next month end"等表达式
+// 以now为基准、在timezone下解析为日期范围。单日表达式的Start与End相同，"上周"、"本月"等为整段范围，周从周一开始
+func ParseDateExpr(expr string, now time.Time, timezone *time.Location) (DateRange, error) {
+	today := StartOfDay(now, timezone)
+	normalized := strings.ToLower(strings.TrimSpace(enWhitespaceRep.ReplaceAllString(expr, " ")))
+	if r, ok := parseZhDateExpr(strings.ReplaceAll(normalized, " ", ""), today); ok {
+		return r, nil
+	}
+	if r, ok := parseEnDateExpr(normalized, today); ok {
+		return r, nil
+	}
+	return DateRange{}, fmt.Errorf("%w: %q", ErrUnrecognizedDateExpr, expr)
+}
+
+// ParseDate 解析日期表达式，返回所在范围第一天的零点
+func ParseDate(expr string, now time.Time, timezone *time.Location) (time.Time, error) {
+	r, err := ParseDateExpr(expr, now, timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return r.Start, nil
+}
+
+func parseZhDateExpr(expr string, today time.Time) (DateRange, bool) {
+	if n, ok := zhRelativeDays[expr]; ok {
+		return singleDay(today.AddDate(0, 0, n)), true
+	}
+	if m := zhAgoRe.FindStringSubmatch(expr); m != nil {
+		n, ok := parseZhNumber(m[1])
+		if !ok {
+			return DateRange{}, false
+		}
+		if strings.HasSuffix(m[3], "前") {
+			n = -n
+		}
+		return singleDay(shiftDate(today, strings.TrimPrefix(m[2], "个"), n)), true
+	}
+	if m := zhWeekRe.FindStringSubmatch(expr); m != nil {
+		start := WeekdayOfWeek(today, time.Monday, zhOffsets[m[1]], time.Monday)
+		switch m[3] {
+		case "":
+			return DateRange{Start: start, End: start.AddDate(0, 0, 6)}, true
+		case "初":
+			return singleDay(start), true
+		case "末":
+			return DateRange{Start: start.AddDate(0, 0, 5), End: start.AddDate(0, 0, 6)}, true
+		}
+		return singleDay(WeekdayOfWeek(start, zhWeekdays[m[3]], 0, time.Monday)), true
+	}
+	if m := zhMonthRe.FindStringSubmatch(expr); m != nil && (m[1] != "" || m[3] != "") {
+		return periodPart(monthRange(today, zhOffsets[m[1]]), m[3]), true
+	}
+	if m := zhYearRe.FindStringSubmatch(expr); m != nil {
+		return periodPart(yearRange(today, zhOffsets[m[1]]), m[2]), true
+	}
+	return DateRange{}, false
+}
+
+func parseEnDateExpr(expr string, today time.Time) (DateRange, bool) {
+	if n, ok := enRelativeDays[expr]; ok {
+		return singleDay(today.AddDate(0, 0, n)), true
+	}
+	if m := enAgoRe.FindStringSubmatch(expr); m != nil {
+		n := parseEnNumber(m[1])
+		if m[3] == "ago" {
+			n = -n
+		}
+		return singleDay(shiftDate(today, m[2], n)), true
+	}
+	if m := enInRe.FindStringSubmatch(expr); m != nil {
+		return singleDay(shiftDate(today, m[2], parseEnNumber(m[1]))), true
+	}
+	if m := enPeriodRe.FindStringSubmatch(expr); m != nil {
+		return periodPart(enPeriod(today, m[2], enOffsets[m[1]]), m[3]), true
+	}
+	if m := enPeriodOfRe.FindStringSubmatch(expr); m != nil {
+		return periodPart(enPeriod(today, m[3], enOffsets[m[2]]), m[1]), true
+	}
+	if expr == "weekend" || expr == "this weekend" {
+		start := WeekdayOfWeek(today, time.Saturday, 0, time.Monday)
+		return DateRange{Start: start, End: start.AddDate(0, 0, 1)}, true
+	}
+	if m := enWeekdayRe.FindStringSubmatch(expr); m != nil {
+		weekday, ok := enWeekdays[m[2]]
+		if !ok {
+			return DateRange{}, false
+		}
+		switch m[1] {
+		case "last", "previous":
+			return singleDay(PreviousWeekday(today, weekday)), true
+		case "next":
+			return singleDay(NextWeekday(today, weekday)), true
+		}
+		return singleDay(WeekdayOfWeek(today, weekday, 0, time.Monday)), true
+	}
+	return DateRange{}, false
+}
+
+// enPeriod 英文周期单位对应的范围
+func enPeriod(today time.Time, unit string, offset int) DateRange {
+	switch unit {
+	case "week":
+		start := WeekdayOfWeek(today, time.Monday, offset, time.Monday)
+		return DateRange{Start: start, End: start.AddDate(0, 0, 6)}
+	case "month":
+		return monthRange(today, offset)
+	}
+	return yearRange(today, offset)
+}
+
+// periodPart 取范围的开头或结尾，part为空时返回整个范围
+func periodPart(r DateRange, part string) DateRange {
+	switch part {
+	case "初", "start", "beginning":
+		return singleDay(r.Start)
+	case "末", "底", "end":
+		return singleDay(r.End)
+	}
+	return r
+}
+
+// shiftDate 按单位偏移日期
+func shiftDate(today time.Time, unit string, n int) time.Time {
+	switch unit {
+	case "周", "星期", "week":
+		return today.AddDate(0, 0, 7*n)
+	case "月", "month":
+		y, m, d := addCivilMonths(today.Year(), today.Month(), today.Day(), n, LeapDayFeb28)
+		return time.Date(y, m, d, 0, 0, 0, 0, today.Location())
+	case "年", "year":
+		y, m, d := addCivilMonths(today.Year(), today.Month(), today.Day(), 12*n, LeapDayFeb28)
+		return time.Date(y, m, d, 0, 0, 0, 0, today.Location())
+	}
+	return today.AddDate(0, 0, n)
+}
+
+func singleDay(t time.Time) DateRange {
+	return DateRange{Start: t, End: t}
+}
+
+func monthRange(today time.Time, offset int) DateRange {
+	start := time.Date(today.Year(), today.Month()+time.Month(offset), 1, 0, 0, 0, 0, today.Location())
+	return DateRange{Start: start, End: start.AddDate(0, 1, -1)}
+}
+
+func yearRange(today time.Time, offset int) DateRange {
+	start := time.Date(today.Year()+offset, time.January, 1, 0, 0, 0, 0, today.Location())
+	return DateRange{Start: start, End: start.AddDate(1, 0, -1)}
+}
+
+func parseEnNumber(s string) int {
+	if n, ok := enNumbers[s]; ok {
+		return n
+	}
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// parseZhNumber 解析阿拉伯数字或一百以内的中文数字
+func parseZhNumber(s string) (int, bool) {
+	if n, err := strconv.Atoi(s); err == nil {
+		return n, true
+	}
+	digits := map[rune]int{'零': 0, '一': 1, '二': 2, '两': 2, '三': 3, '四': 4, '五': 5, '六': 6, '七': 7, '八': 8, '九': 9}
+	runes := []rune(s)
+	tens, ones := 0, 0
+	for i, r := range runes {
+		if r == '十' {
+			if i > 1 || tens != 0 {
+				return 0, false
+			}
+			tens = ones
+			if i == 0 {
+				tens = 1
+			}
+			ones = 0
+			if tens == 0 {
+				return 0, false
+			}
+			continue
+		}
+		d, ok := digits[r]
+		if !ok || (i > 0 && runes[i-1] != '十') {
+			return 0, false
+		}
+		ones = d
+	}
+	return tens*10 + ones, true
+}
@@ -0,0 +1,72 @@
+package timeutil
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseDateExpr(t *testing.T) {
+	loc := getTestTimezone()
+	now := fixedTime(loc) // 2024-07-28 10:15:30 Sunday
+
+	tests := []struct {
+		expr string
+		from string
+		to   string
+	}{
+		{"今天", "20240728", "20240728"},
+		{"昨天", "20240727", "20240727"},
+		{"大前天", "20240725", "20240725"},
+		{"3天前", "20240725", "20240725"},
+		{"十五天后", "20240812", "20240812"},
+		{"两个月前", "20240528", "20240528"},
+		{"上周一", "20240715", "20240715"},
+		{"本周", "20240722", "20240728"},
+		{"下周末", "20240803", "20240804"},
+		{"星期天", "20240728", "20240728"},
+		{"本月初", GetMonthFirstDay("20240728", loc), GetMonthFirstDay("20240728", loc)},
+		{"月底", GetMonthLastDay("20240728", loc), GetMonthLastDay("20240728", loc)},
+		{"上个月", "20240601", "20240630"},
+		{"去年底", "20231231", "20231231"},
+		{"today", "20240728", "20240728"},
+		{"Day before  yesterday", "20240726", "20240726"},
+		{"last friday", "20240726", "20240726"},
+		{"next monday", "20240729", "20240729"},
+		{"previous sunday", "20240721", "20240721"},
+		{"3 days ago", "20240725", "20240725"},
+		{"in two weeks", "20240811", "20240811"},
+		{"a month later", "20240828", "20240828"},
+		{"next month end", "20240831", "20240831"},
+		{"end of last month", "20240630", "20240630"},
+		{"this week", "20240722", "20240728"},
+		{"next year", "20250101", "20251231"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseDateExpr(tt.expr, now, loc)
+			if err != nil {
+				t.Fatalf("ParseDateExpr(%q) error = %v", tt.expr, err)
+			}
+			if from, to := got.Start.Format(FormatYYYYMMDDNoSymbol), got.End.Format(FormatYYYYMMDDNoSymbol); from != tt.from || to != tt.to {
+				t.Errorf("ParseDateExpr(%q) = %v-%v; want %v-%v", tt.expr, from, to, tt.from, tt.to)
+			}
+		})
+	}
+}
+
+func TestParseDateExprError(t *testing.T) {
+	loc := getTestTimezone()
+	for _, expr := range []string{"", "上上上周", "someday", "一百天前", "月"} {
+		if _, err := ParseDateExpr(expr, fixedTime(loc), loc); !errors.Is(err, ErrUnrecognizedDateExpr) {
+			t.Errorf("ParseDateExpr(%q) error = %v; want ErrUnrecognizedDateExpr", expr, err)
+		}
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	loc := getTestTimezone()
+	got, err := ParseDate("上周", fixedTime(loc), loc)
+	if err != nil || got.Format(FormatYYYYMMDDHHMMSS) != "2024-07-15 00:00:00" {
+		t.Errorf("ParseDate(上周) = %v, %v; want 2024-07-15 00:00:00", got, err)
+	}
+}
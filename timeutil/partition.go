@@ -0,0 +1,178 @@
+package timeutil
+
+import (
+	"strings"
+	"time"
+)
+
+// PartitionGranularity 分区粒度
+type PartitionGranularity int
+
+const (
+	PartitionDay  PartitionGranularity = iota // 天分区，规范格式YYYYMMDD
+	PartitionHour                             // 小时分区，规范格式YYYYMMDDHH
+)
+
+// PartitionRange 连续的分区范围，包括起止点
+type PartitionRange struct {
+	From string
+	To   string
+}
+
+// PartitionReport 分区检查结果，Missing、Duplicate、MissingRanges均为规范格式
+type PartitionReport struct {
+	Missing       []string         // 期望范围内缺失的分区
+	Duplicate     []string         // 期望范围内重复出现的分区
+	Unexpected    []string         // 无法解析或不在期望范围内的分区，保留原始字符串
+	MissingRanges []PartitionRange // 缺失分区合并后的连续范围，可用于补数
+}
+
+// IsComplete 期望范围内的分区是否齐全
+func (r PartitionReport) IsComplete() bool {
+	return len(r.Missing) == 0
+}
+
+// layout 粒度对应的规范格式
+func (g PartitionGranularity) layout() string {
+	if g == PartitionHour {
+		return FormatYYYYMMDDHHNoSymbol
+	}
+	return FormatYYYYMMDDNoSymbol
+}
+
+// step 粒度对应的步长
+func (g PartitionGranularity) step() time.Duration {
+	if g == PartitionHour {
+		return time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// ParsePartition 解析分区字符串，支持"20240701"、"2024-07-01"、"dt=2024-07-01/hr=08"、"db1.tbl/dt=2024-07-01"、"2024070108"等格式，
+// 返回规范格式的分区值。包含dt=、hr=时取其值，否则取路径中最后一个日期形式的部分
+func ParsePartition(partition string, granularity PartitionGranularity) (string, bool) {
+	layout := granularity.layout()
+	str := partitionDigits(partition)
+	if len(str) < len(layout) {
+		return "", false
+	}
+	// 多余的分钟、秒数必须为0，例如"2024-07-01 08:00:00"
+	if strings.Trim(str[len(layout):], "0") != "" {
+		return "", false
+	}
+	str = str[:len(layout)]
+	if !VerifyDateLayout(str, layout) {
+		return "", false
+	}
+	return str, true
+}
+
+// partitionDigits 提取分区中日期时间部分的数字，不是日期形式时返回空字符串
+func partitionDigits(partition string) string {
+	segments := strings.Split(partition, "/")
+	var day, hour string
+	for _, segment := range segments {
+		key, value, ok := strings.Cut(segment, "=")
+		if !ok {
+			continue
+		}
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "dt":
+			day = value
+		case "hr":
+			hour = value
+		}
+	}
+	if day != "" {
+		dayDigits, ok1 := dateDigits(day)
+		hourDigits, ok2 := dateDigits(hour)
+		if !ok1 || !ok2 {
+			return ""
+		}
+		return dayDigits + hourDigits
+	}
+
+	for i := len(segments) - 1; i >= 0; i-- {
+		segment := segments[i]
+		if _, value, ok := strings.Cut(segment, "="); ok {
+			segment = value
+		}
+		if digits, ok := dateDigits(segment); ok && len(digits) >= len(FormatYYYYMMDDNoSymbol) {
+			return digits
+		}
+	}
+	return ""
+}
+
+// dateDigits 返回日期形式字符串中的数字，只允许数字和"-"、":"、空格、"T"分隔符
+func dateDigits(value string) (string, bool) {
+	var digits strings.Builder
+	for _, r := range strings.TrimSpace(value) {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '-' || r == ':' || r == ' ' || r == 'T':
+		default:
+			return "", false
+		}
+	}
+	return digits.String(), true
+}
+
+// GetRangePartition 获取从某分区到某分区的所有分区，包括起止点，格式为规范格式
+func GetRangePartition(from string, to string, granularity PartitionGranularity) []string {
+	if granularity == PartitionDay {
+		return GetRangeBiDay(from, to)
+	}
+	layout := granularity.layout()
+	begin := Str2Time(from, layout, TimezoneUtc)
+	end := Str2Time(to, layout, TimezoneUtc)
+	var ret []string
+	for t := begin; !t.After(end); t = t.Add(granularity.step()) {
+		ret = append(ret, t.Format(layout))
+	}
+	return ret
+}
+
+// CheckPartitions 检查期望范围from-to(包括起止点)内的分区是否齐全。
+// from、to及partitions可以是ParsePartition支持的任意格式，分区按字面值比较，不做时区转换
+func CheckPartitions(from string, to string, granularity PartitionGranularity, partitions []string) PartitionReport {
+	var report PartitionReport
+	begin, ok1 := ParsePartition(from, granularity)
+	end, ok2 := ParsePartition(to, granularity)
+	if !ok1 || !ok2 || begin > end {
+		report.Unexpected = append(report.Unexpected, partitions...)
+		return report
+	}
+
+	counts := make(map[string]int, len(partitions))
+	for _, p := range partitions {
+		value, ok := ParsePartition(p, granularity)
+		if !ok || value < begin || value > end {
+			report.Unexpected = append(report.Unexpected, p)
+			continue
+		}
+		counts[value]++
+		if counts[value] == 2 {
+			report.Duplicate = append(report.Duplicate, value)
+		}
+	}
+
+	layout := granularity.layout()
+	for _, value := range GetRangePartition(begin, end, granularity) {
+		if counts[value] > 0 {
+			continue
+		}
+		report.Missing = append(report.Missing, value)
+		last := len(report.MissingRanges) - 1
+		if last >= 0 {
+			prev := Str2Time(report.MissingRanges[last].To, layout, TimezoneUtc)
+			if prev.Add(granularity.step()).Format(layout) == value {
+				report.MissingRanges[last].To = value
+				continue
+			}
+		}
+		report.MissingRanges = append(report.MissingRanges, PartitionRange{From: value, To: value})
+	}
+	return report
+}
@@ -0,0 +1,81 @@
+package timeutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePartition(t *testing.T) {
+	tests := []struct {
+		partition   string
+		granularity PartitionGranularity
+		expected    string
+		ok          bool
+	}{
+		{"20240701", PartitionDay, "20240701", true},
+		{"2024-07-01", PartitionDay, "20240701", true},
+		{"dt=2024-07-01", PartitionDay, "20240701", true},
+		{"2024-07-01 00:00:00", PartitionDay, "20240701", true},
+		{"2024-07-01 08:00:00", PartitionDay, "", false},
+		{"20240732", PartitionDay, "", false},
+		{"dt=2024-07-01/hr=08", PartitionHour, "2024070108", true},
+		{"2024070123", PartitionHour, "2024070123", true},
+		{"20240701", PartitionHour, "", false},
+		{"v2/dt=20240701", PartitionDay, "20240701", true},
+		{"db1.tbl/dt=2024-07-01", PartitionDay, "20240701", true},
+		{"db1.tbl/dt=2024-07-01/hr=08", PartitionHour, "2024070108", true},
+		{"v2/20240701", PartitionDay, "20240701", true},
+		{"dt=v2", PartitionDay, "", false},
+	}
+
+	for _, test := range tests {
+		result, ok := ParsePartition(test.partition, test.granularity)
+		if result != test.expected || ok != test.ok {
+			t.Errorf("ParsePartition(%q, %v) = %v, %v; want %v, %v", test.partition, test.granularity, result, ok, test.expected, test.ok)
+		}
+	}
+}
+
+func TestCheckPartitions(t *testing.T) {
+	tests := []struct {
+		name        string
+		from        string
+		to          string
+		granularity PartitionGranularity
+		partitions  []string
+		expected    PartitionReport
+	}{
+		{
+			name:        "day",
+			from:        "20240701",
+			to:          "2024-07-07",
+			granularity: PartitionDay,
+			partitions:  []string{"dt=2024-07-01", "20240702", "2024-07-02", "20240705", "20240630", "bad"},
+			expected: PartitionReport{
+				Missing:       []string{"20240703", "20240704", "20240706", "20240707"},
+				Duplicate:     []string{"20240702"},
+				Unexpected:    []string{"20240630", "bad"},
+				MissingRanges: []PartitionRange{{"20240703", "20240704"}, {"20240706", "20240707"}},
+			},
+		},
+		{
+			name:        "hour across day",
+			from:        "2024070122",
+			to:          "2024070201",
+			granularity: PartitionHour,
+			partitions:  []string{"dt=20240701/hr=22", "dt=20240702/hr=01"},
+			expected: PartitionReport{
+				Missing:       []string{"2024070123", "2024070200"},
+				MissingRanges: []PartitionRange{{"2024070123", "2024070200"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CheckPartitions(tt.from, tt.to, tt.granularity, tt.partitions); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("CheckPartitions() = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package timeutil
+
+import (
+	"time"
+)
+
+// Period 时间粒度，可以是固定时长，也可以是日、周、月、季、年等自然周期
+type Period struct {
+	duration  time.Duration
+	months    int
+	days      int
+	weekStart time.Weekday
+}
+
+// PeriodOf 固定时长的粒度。不足一天时按当地零点对齐，例如5分钟、15分钟、1小时；恰好24小时等同于DailyPeriod；
+// 超过一天时按Unix纪元(1970-01-01 00:00:00 UTC)对齐，是固定的纳秒时长，不随夏令时调整，只有DailyPeriod等自然周期按日历对齐
+func PeriodOf(d time.Duration) Period {
+	if d == 24*time.Hour {
+		return DailyPeriod()
+	}
+	return Period{duration: d}
+}
+
+// DailyPeriod 自然日粒度
+func DailyPeriod() Period {
+	return Period{days: 1}
+}
+
+// WeeklyPeriod 自然周粒度，weekStart为每周的第一天
+func WeeklyPeriod(weekStart time.Weekday) Period {
+	return Period{days: 7, weekStart: weekStart}
+}
+
+// MonthlyPeriod 自然月粒度
+func MonthlyPeriod() Period {
+	return Period{months: 1}
+}
+
+// QuarterlyPeriod 自然季度粒度
+func QuarterlyPeriod() Period {
+	return Period{months: 3}
+}
+
+// YearlyPeriod 自然年粒度
+func YearlyPeriod() Period {
+	return Period{months: 12}
+}
+
+// IsZero 是否为未设置的粒度
+func (p Period) IsZero() bool {
+	return p.duration <= 0 && p.months <= 0 && p.days <= 0
+}
+
+// Start 获取t所在周期的开始时间
+func (p Period) Start(t time.Time, timezone *time.Location) time.Time {
+	t = t.In(timezone)
+	y, m, d := t.Date()
+	switch {
+	case p.months > 0:
+		month := (int(m)-1)/p.months*p.months + 1
+		return startOfDate(y, time.Month(month), 1, timezone)
+	case p.days == 7:
+		offset := (int(t.Weekday()) - int(p.weekStart) + 7) % 7
+		return startOfDate(y, m, d-offset, timezone)
+	case p.days > 0:
+		return startOfDate(y, m, d, timezone)
+	case p.duration > 24*time.Hour:
+		offset := time.Duration(t.UnixNano() % int64(p.duration))
+		if offset < 0 {
+			offset += p.duration
+		}
+		return t.Add(-offset)
+	case p.duration > 0:
+		midnight := startOfDate(y, m, d, timezone)
+		return midnight.Add(t.Sub(midnight) / p.duration * p.duration)
+	}
+	return t
+}
+
+// Next 获取start所在周期的下一个周期的开始时间
+func (p Period) Next(start time.Time, timezone *time.Location) time.Time {
+	start = p.Start(start, timezone)
+	switch {
+	case p.months > 0:
+		y, m, _ := start.Date()
+		return startOfDate(y, m+time.Month(p.months), 1, timezone)
+	case p.days > 0:
+		y, m, d := start.Date()
+		return startOfDate(y, m, d+p.days, timezone)
+	case p.duration > 0:
+		// 不能整除一天的时长在次日零点重新对齐
+		return p.Start(start.Add(p.duration), timezone)
+	}
+	return start
+}
@@ -0,0 +1,57 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPeriodStart(t *testing.T) {
+	loc := getTestTimezone()
+	tm := fixedTime(loc) // 2024-07-28 10:15:30 Sunday
+	tests := []struct {
+		name     string
+		period   Period
+		expected time.Time
+	}{
+		{"5 minute", PeriodOf(5 * time.Minute), GetTime5Minute(tm.Unix(), loc)},
+		{"15 minute", PeriodOf(15 * time.Minute), GetTime15Minute(tm.Unix(), loc)},
+		{"7 hour", PeriodOf(7 * time.Hour), time.Date(2024, 7, 28, 7, 0, 0, 0, loc)},
+		{"day", DailyPeriod(), time.Date(2024, 7, 28, 0, 0, 0, 0, loc)},
+		{"24 hour is daily", PeriodOf(24 * time.Hour), time.Date(2024, 7, 28, 0, 0, 0, 0, loc)},
+		{"3 day from unix epoch", PeriodOf(72 * time.Hour), time.Date(2024, 7, 28, 0, 0, 0, 0, time.UTC)},
+		{"week from monday", WeeklyPeriod(time.Monday), time.Date(2024, 7, 22, 0, 0, 0, 0, loc)},
+		{"week from sunday", WeeklyPeriod(time.Sunday), time.Date(2024, 7, 28, 0, 0, 0, 0, loc)},
+		{"month", MonthlyPeriod(), time.Date(2024, 7, 1, 0, 0, 0, 0, loc)},
+		{"quarter", QuarterlyPeriod(), time.Date(2024, 7, 1, 0, 0, 0, 0, loc)},
+		{"year", YearlyPeriod(), time.Date(2024, 1, 1, 0, 0, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.period.Start(tm, loc); !got.Equal(tt.expected) {
+				t.Errorf("Start() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPeriodNext(t *testing.T) {
+	la := TimezoneLa
+	tests := []struct {
+		name     string
+		period   Period
+		start    time.Time
+		expected time.Time
+	}{
+		{"dst day has 23 hours", DailyPeriod(), time.Date(2024, 3, 10, 0, 0, 0, 0, la), time.Date(2024, 3, 11, 0, 0, 0, 0, la)},
+		{"realign at midnight", PeriodOf(7 * time.Hour), time.Date(2024, 7, 1, 21, 0, 0, 0, la), time.Date(2024, 7, 2, 0, 0, 0, 0, la)},
+		{"24 hour follows dst", PeriodOf(24 * time.Hour), time.Date(2024, 3, 10, 0, 0, 0, 0, la), time.Date(2024, 3, 11, 0, 0, 0, 0, la)},
+		{"month end", MonthlyPeriod(), time.Date(2024, 1, 31, 0, 0, 0, 0, la), time.Date(2024, 2, 1, 0, 0, 0, 0, la)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.period.Next(tt.start, la); !got.Equal(tt.expected) {
+				t.Errorf("Next() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,476 @@
+package timeutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrLimitExceeded 请求超出限流器容量，或在ctx截止前无法获得许可
+var ErrLimitExceeded = errors.New("timeutil: rate limit exceeded")
+
+// Reservation 预留的许可，调用方应在TimeToAct之后执行操作
+type Reservation struct {
+	ok        bool
+	timeToAct time.Time
+	clock     Clock
+	limiter   *limiter
+	n         int
+}
+
+// OK 是否预留成功，请求数超过限流器容量时为false
+func (r Reservation) OK() bool {
+	return r.ok
+}
+
+// TimeToAct 可以执行操作的时间
+func (r Reservation) TimeToAct() time.Time {
+	return r.timeToAct
+}
+
+// Delay 距可以执行操作还需等待的时长，OK为false时无意义
+func (r Reservation) Delay() time.Duration {
+	if d := r.timeToAct.Sub(r.clock.Now()); d > 0 {
+		return d
+	}
+	return 0
+}
+
+// Cancel 放弃预留，归还尚未被之后的预留占用的许可。已到执行时间或预留失败时不做任何事，同一预留只应取消一次
+func (r Reservation) Cancel() {
+	if r.ok && r.limiter != nil {
+		r.limiter.cancel(r)
+	}
+}
+
+// Limiter 限流器
+type Limiter interface {
+	// Allow 当前是否允许1个请求，允许时消耗许可
+	Allow() bool
+	// AllowN 当前是否允许n个请求，允许时消耗许可
+	AllowN(n int) bool
+	// Reserve 预留1个许可
+	Reserve() Reservation
+	// ReserveN 预留n个许可
+	ReserveN(n int) Reservation
+	// Wait 等待直到获得1个许可，ctx结束或截止前无法获得许可时返回错误
+	Wait(ctx context.Context) error
+	// WaitN 等待直到获得n个许可
+	WaitN(ctx context.Context, n int) error
+}
+
+// limiterAlgorithm 限流算法，调用方负责加锁。
+// reserve尝试在now预留n个许可，需等待的时长超过maxWait时不修改状态并返回false；
+// cancel归还在timeToAct预留的n个许可，已被之后的预留占用的部分不归还；
+// full判断在now时许可是否已全部恢复，即状态与新建时等价
+type limiterAlgorithm interface {
+	reserve(now time.Time, n int, maxWait time.Duration) (time.Time, bool)
+	cancel(now time.Time, timeToAct time.Time, n int)
+	full(now time.Time) bool
+}
+
+// limiter 基于限流算法实现Limiter
+type limiter struct {
+	mu        sync.Mutex
+	clock     Clock
+	algorithm limiterAlgorithm
+}
+
+const maxWaitForever = time.Duration(1<<63 - 1)
+
+func newLimiter(algorithm limiterAlgorithm, clock Clock) *limiter {
+	return &limiter{clock: orSystemClock(clock), algorithm: algorithm}
+}
+
+func (l *limiter) reserve(n int, maxWait time.Duration) Reservation {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	timeToAct, ok := l.algorithm.reserve(l.clock.Now(), n, maxWait)
+	return Reservation{ok: ok, timeToAct: timeToAct, clock: l.clock, limiter: l, n: n}
+}
+
+func (l *limiter) cancel(r Reservation) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.clock.Now()
+	if r.timeToAct.Before(now) {
+		return
+	}
+	l.algorithm.cancel(now, r.timeToAct, r.n)
+}
+
+func (l *limiter) full() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.algorithm.full(l.clock.Now())
+}
+
+func (l *limiter) Allow() bool {
+	return l.AllowN(1)
+}
+
+func (l *limiter) AllowN(n int) bool {
+	return l.reserve(n, 0).ok
+}
+
+func (l *limiter) Reserve() Reservation {
+	return l.ReserveN(1)
+}
+
+func (l *limiter) ReserveN(n int) Reservation {
+	return l.reserve(n, maxWaitForever)
+}
+
+func (l *limiter) Wait(ctx context.Context) error {
+	return l.WaitN(ctx, 1)
+}
+
+func (l *limiter) WaitN(ctx context.Context, n int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	maxWait := maxWaitForever
+	if deadline, ok := ctx.Deadline(); ok {
+		maxWait = deadline.Sub(l.clock.Now())
+		if maxWait <= 0 {
+			return context.DeadlineExceeded
+		}
+	}
+	r := l.reserve(n, maxWait)
+	if !r.ok {
+		return fmt.Errorf("%w: cannot acquire %d permits", ErrLimitExceeded, n)
+	}
+	if err := sleepContext(ctx, l.clock, r.Delay()); err != nil {
+		// 未等到许可就结束，归还预留的许可
+		r.Cancel()
+		return err
+	}
+	return nil
+}
+
+// NewTokenBucket 令牌桶限流器，每interval产生1个令牌，最多积累burst个令牌
+func NewTokenBucket(interval time.Duration, burst int, clock Clock) Limiter {
+	return newLimiter(&tokenBucket{interval: interval, burst: burst, tokens: float64(burst)}, clock)
+}
+
+type tokenBucket struct {
+	interval  time.Duration
+	burst     int
+	tokens    float64
+	last      time.Time
+	lastEvent time.Time // 最晚一次预留的执行时间
+}
+
+// advance 截至now的令牌数，不超过burst
+func (b *tokenBucket) advance(now time.Time) float64 {
+	tokens := b.tokens
+	if !b.last.IsZero() && now.After(b.last) {
+		tokens += float64(now.Sub(b.last)) / float64(b.interval)
+	}
+	if tokens > float64(b.burst) {
+		tokens = float64(b.burst)
+	}
+	return tokens
+}
+
+func (b *tokenBucket) reserve(now time.Time, n int, maxWait time.Duration) (time.Time, bool) {
+	if n <= 0 || n > b.burst || b.interval <= 0 {
+		return now, false
+	}
+	tokens := b.advance(now) - float64(n)
+
+	var wait time.Duration
+	if tokens < 0 {
+		wait = time.Duration(-tokens * float64(b.interval))
+	}
+	if wait > maxWait {
+		return now, false
+	}
+	if now.After(b.last) {
+		b.last = now
+	}
+	b.tokens = tokens
+	timeToAct := now.Add(wait)
+	if timeToAct.After(b.lastEvent) {
+		b.lastEvent = timeToAct
+	}
+	return timeToAct, true
+}
+
+func (b *tokenBucket) cancel(now time.Time, timeToAct time.Time, n int) {
+	// 之后的预留按本次预留后的令牌数计算等待时长，它们占用的令牌不归还
+	restore := float64(n) - float64(b.lastEvent.Sub(timeToAct))/float64(b.interval)
+	if restore <= 0 {
+		return
+	}
+	b.tokens = b.advance(now) + restore
+	if b.tokens > float64(b.burst) {
+		b.tokens = float64(b.burst)
+	}
+	if now.After(b.last) {
+		b.last = now
+	}
+}
+
+func (b *tokenBucket) full(now time.Time) bool {
+	return b.advance(now) >= float64(b.burst) && !b.lastEvent.After(now)
+}
+
+// NewLeakyBucket 漏桶限流器，请求按每interval一个的速率匀速放行，最多排队capacity个请求。
+// 只有无需排队时Allow才返回true
+func NewLeakyBucket(interval time.Duration, capacity int, clock Clock) Limiter {
+	return newLimiter(&leakyBucket{interval: interval, capacity: capacity}, clock)
+}
+
+type leakyBucket struct {
+	interval time.Duration
+	capacity int
+	next     time.Time // 下一个请求最早的放行时间
+}
+
+func (b *leakyBucket) reserve(now time.Time, n int, maxWait time.Duration) (time.Time, bool) {
+	start := b.next
+	if start.Before(now) {
+		start = now
+	}
+	if n <= 0 || b.interval <= 0 {
+		return now, false
+	}
+	// 排队中的请求数，不含下一个即将放行的请求
+	queued := int((start.Sub(now)+b.interval-1)/b.interval) - 1
+	if queued < 0 {
+		queued = 0
+	}
+	if queued+n > b.capacity {
+		return now, false
+	}
+	timeToAct := start.Add(time.Duration(n-1) * b.interval)
+	if timeToAct.Sub(now) > maxWait {
+		return now, false
+	}
+	b.next = timeToAct.Add(b.interval)
+	return timeToAct, true
+}
+
+func (b *leakyBucket) cancel(now time.Time, timeToAct time.Time, n int) {
+	// 只有最后一个预留可以归还，否则之后的请求已按它排队
+	if b.next.Equal(timeToAct.Add(b.interval)) {
+		b.next = timeToAct.Add(-time.Duration(n-1) * b.interval)
+	}
+}
+
+func (b *leakyBucket) full(now time.Time) bool {
+	return !b.next.After(now)
+}
+
+// NewSlidingWindowLog 滑动窗口日志限流器，任意长度为window的时间段内最多允许limit个请求
+func NewSlidingWindowLog(limit int, window time.Duration, clock Clock) Limiter {
+	return newLimiter(&slidingWindowLog{limit: limit, window: window}, clock)
+}
+
+type slidingWindowLog struct {
+	limit  int
+	window time.Duration
+	log    []time.Time
+}
+
+func (w *slidingWindowLog) reserve(now time.Time, n int, maxWait time.Duration) (time.Time, bool) {
+	if n <= 0 || n > w.limit {
+		return now, false
+	}
+	expired := 0
+	for expired < len(w.log) && !w.log[expired].After(now.Add(-w.window)) {
+		expired++
+	}
+	w.log = w.log[expired:]
+
+	timeToAct := now
+	if len(w.log)+n > w.limit {
+		timeToAct = w.log[len(w.log)+n-w.limit-1].Add(w.window)
+	}
+	if last := len(w.log) - 1; last >= 0 && w.log[last].After(timeToAct) {
+		timeToAct = w.log[last]
+	}
+	if timeToAct.Sub(now) > maxWait {
+		return now, false
+	}
+	for i := 0; i < n; i++ {
+		w.log = append(w.log, timeToAct)
+	}
+	return timeToAct, true
+}
+
+func (w *slidingWindowLog) cancel(now time.Time, timeToAct time.Time, n int) {
+	// 同一时刻的记录可以互换，从后往前删除n条即可
+	for i := len(w.log) - 1; i >= 0 && n > 0; i-- {
+		if w.log[i].Equal(timeToAct) {
+			w.log = append(w.log[:i], w.log[i+1:]...)
+			n--
+		}
+	}
+}
+
+func (w *slidingWindowLog) full(now time.Time) bool {
+	return len(w.log) == 0 || !w.log[len(w.log)-1].After(now.Add(-w.window))
+}
+
+// NewFixedWindow 固定窗口限流器，窗口按period在timezone下对齐(例如PeriodOf(time.Hour)与GetTime1Hour一致)，每个窗口最多允许limit个请求
+func NewFixedWindow(limit int, period Period, timezone *time.Location, clock Clock) Limiter {
+	return newLimiter(&fixedWindow{limit: limit, period: period, timezone: timezone}, clock)
+}
+
+type fixedWindow struct {
+	limit    int
+	period   Period
+	timezone *time.Location
+	start    time.Time // 当前窗口的开始时间
+	count    int       // 从当前窗口开始已预留的请求数，超过limit的部分属于后续窗口
+}
+
+func (w *fixedWindow) reserve(now time.Time, n int, maxWait time.Duration) (time.Time, bool) {
+	if n <= 0 || n > w.limit || w.period.IsZero() {
+		return now, false
+	}
+	start, count := w.start, w.count
+	current := w.period.Start(now, w.timezone)
+	if count == 0 || start.IsZero() {
+		start = current
+	}
+	for start.Before(current) && count > 0 {
+		start = w.period.Next(start, w.timezone)
+		count -= w.limit
+	}
+	if count <= 0 {
+		start, count = current, 0
+	}
+	// n个请求需落在同一窗口内
+	if count%w.limit+n > w.limit {
+		count += w.limit - count%w.limit
+	}
+
+	timeToAct := start
+	for k := (count + n - 1) / w.limit; k > 0; k-- {
+		timeToAct = w.period.Next(timeToAct, w.timezone)
+	}
+	if timeToAct.Before(now) {
+		timeToAct = now
+	}
+	if timeToAct.Sub(now) > maxWait {
+		return now, false
+	}
+	w.start, w.count = start, count+n
+	return timeToAct, true
+}
+
+func (w *fixedWindow) cancel(now time.Time, timeToAct time.Time, n int) {
+	// 预留所在的窗口已被清零时无需归还
+	if !timeToAct.Before(w.start) && w.count >= n {
+		w.count -= n
+	}
+}
+
+func (w *fixedWindow) full(now time.Time) bool {
+	if w.count == 0 {
+		return true
+	}
+	// 最后一个预留所在的窗口已经结束
+	last := w.start
+	for k := (w.count - 1) / w.limit; k > 0; k-- {
+		last = w.period.Next(last, w.timezone)
+	}
+	return w.period.Start(now, w.timezone).After(last)
+}
+
+// KeyedLimiter 按key分别限流，空闲超过idleTTL且许可已全部恢复的key会被自动清理，
+// 清理不会让key提前获得完整的配额
+type KeyedLimiter[K comparable] struct {
+	mu         sync.Mutex
+	clock      Clock
+	newLimiter func() Limiter
+	idleTTL    time.Duration
+	entries    map[K]*keyedLimiterEntry
+	lastSweep  time.Time
+}
+
+type keyedLimiterEntry struct {
+	limiter  Limiter
+	lastSeen time.Time
+}
+
+// NewKeyedLimiter 创建按key限流的限流器，newLimiter为每个新key创建限流器，idleTTL<=0时不清理
+func NewKeyedLimiter[K comparable](newLimiter func() Limiter, idleTTL time.Duration, clock Clock) *KeyedLimiter[K] {
+	clock = orSystemClock(clock)
+	return &KeyedLimiter[K]{
+		clock:      clock,
+		newLimiter: newLimiter,
+		idleTTL:    idleTTL,
+		entries:    make(map[K]*keyedLimiterEntry),
+		lastSweep:  clock.Now(),
+	}
+}
+
+// Get 获取key对应的限流器，不存在时创建
+func (k *KeyedLimiter[K]) Get(key K) Limiter {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	now := k.clock.Now()
+	if k.idleTTL > 0 && now.Sub(k.lastSweep) >= k.idleTTL {
+		for key, entry := range k.entries {
+			if now.Sub(entry.lastSeen) >= k.idleTTL && limiterFull(entry.limiter) {
+				delete(k.entries, key)
+			}
+		}
+		k.lastSweep = now
+	}
+	entry, ok := k.entries[key]
+	if !ok {
+		entry = &keyedLimiterEntry{limiter: k.newLimiter()}
+		k.entries[key] = entry
+	}
+	entry.lastSeen = now
+	return entry.limiter
+}
+
+// limiterFull 限流器的许可是否已全部恢复，无法判断的限流器视为已恢复
+func limiterFull(l Limiter) bool {
+	if f, ok := l.(interface{ full() bool }); ok {
+		return f.full()
+	}
+	return true
+}
+
+// Allow key当前是否允许1个请求
+func (k *KeyedLimiter[K]) Allow(key K) bool {
+	return k.Get(key).Allow()
+}
+
+// AllowN key当前是否允许n个请求
+func (k *KeyedLimiter[K]) AllowN(key K, n int) bool {
+	return k.Get(key).AllowN(n)
+}
+
+// Reserve 为key预留1个许可
+func (k *KeyedLimiter[K]) Reserve(key K) Reservation {
+	return k.Get(key).Reserve()
+}
+
+// Wait 等待直到key获得1个许可
+func (k *KeyedLimiter[K]) Wait(ctx context.Context, key K) error {
+	return k.Get(key).Wait(ctx)
+}
+
+// Remove 删除key对应的限流器
+func (k *KeyedLimiter[K]) Remove(key K) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	delete(k.entries, key)
+}
+
+// Len 当前保留的key数量
+func (k *KeyedLimiter[K]) Len() int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return len(k.entries)
+}
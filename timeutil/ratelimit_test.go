@@ -0,0 +1,196 @@
+package timeutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTokenBucket(t *testing.T) {
+	clock := newStepClock(fixedTime(getTestTimezone()))
+	l := NewTokenBucket(100*time.Millisecond, 3, clock)
+
+	for i := 0; i < 3; i++ {
+		if !l.Allow() {
+			t.Fatalf("Allow() #%d = false; want true", i)
+		}
+	}
+	if l.Allow() {
+		t.Errorf("Allow() after burst = true; want false")
+	}
+	clock.Advance(100 * time.Millisecond)
+	if !l.Allow() || l.Allow() {
+		t.Errorf("Allow() after one interval should allow exactly one request")
+	}
+	if r := l.ReserveN(2); !r.OK() || r.Delay() != 200*time.Millisecond {
+		t.Errorf("ReserveN(2) = %v, %v; want true, 200ms", r.OK(), r.Delay())
+	}
+	if r := l.ReserveN(4); r.OK() {
+		t.Errorf("ReserveN(4) over burst OK = true; want false")
+	}
+	if l.AllowN(0) || l.ReserveN(-1).OK() {
+		t.Errorf("AllowN(0) or ReserveN(-1) OK = true; want false")
+	}
+}
+
+func TestLeakyBucket(t *testing.T) {
+	clock := newStepClock(fixedTime(getTestTimezone()))
+	l := NewLeakyBucket(time.Second, 2, clock)
+
+	if !l.Allow() || l.Allow() {
+		t.Errorf("Allow() should only pass the first request immediately")
+	}
+	if r := l.Reserve(); !r.OK() || r.Delay() != time.Second {
+		t.Errorf("Reserve() = %v, %v; want true, 1s", r.OK(), r.Delay())
+	}
+	if r := l.Reserve(); !r.OK() || r.Delay() != 2*time.Second {
+		t.Errorf("Reserve() = %v, %v; want true, 2s", r.OK(), r.Delay())
+	}
+	if r := l.Reserve(); r.OK() {
+		t.Errorf("Reserve() with full queue OK = true; want false")
+	}
+	clock.Advance(3 * time.Second)
+	if !l.Allow() {
+		t.Errorf("Allow() after queue drained = false; want true")
+	}
+}
+
+func TestSlidingWindowLog(t *testing.T) {
+	clock := newStepClock(fixedTime(getTestTimezone()))
+	l := NewSlidingWindowLog(2, time.Minute, clock)
+
+	l.Allow()
+	clock.Advance(30 * time.Second)
+	l.Allow()
+	if l.Allow() {
+		t.Errorf("Allow() over limit = true; want false")
+	}
+	if r := l.Reserve(); !r.OK() || r.Delay() != 30*time.Second {
+		t.Errorf("Reserve() = %v, %v; want true, 30s", r.OK(), r.Delay())
+	}
+	clock.Advance(30 * time.Second)
+	if l.Allow() {
+		t.Errorf("Allow() with reserved slot = true; want false")
+	}
+	clock.Advance(30 * time.Second)
+	if !l.Allow() {
+		t.Errorf("Allow() after window slid = false; want true")
+	}
+}
+
+func TestFixedWindow(t *testing.T) {
+	loc := getTestTimezone()
+	clock := newStepClock(time.Date(2024, 7, 28, 10, 59, 0, 0, loc))
+	l := NewFixedWindow(2, PeriodOf(time.Hour), loc, clock)
+
+	if !l.Allow() || !l.Allow() || l.Allow() {
+		t.Errorf("Allow() should pass exactly 2 requests in the window")
+	}
+	nextHour := GetTime1Hour(clock.Now().Unix(), loc).Add(time.Hour)
+	if r := l.Reserve(); !r.OK() || !r.TimeToAct().Equal(nextHour) {
+		t.Errorf("Reserve() = %v, %v; want true, %v", r.OK(), r.TimeToAct(), nextHour)
+	}
+	clock.Advance(time.Minute)
+	if !l.Allow() || l.Allow() {
+		t.Errorf("Allow() in next window should pass exactly 1 request after the reservation")
+	}
+}
+
+func TestLimiterWait(t *testing.T) {
+	clock := NewFakeClock(fixedTime(getTestTimezone()))
+	l := NewTokenBucket(time.Second, 1, clock)
+	l.Allow()
+
+	done := make(chan error, 1)
+	go func() { done <- l.Wait(context.Background()) }()
+	clock.BlockUntil(1)
+	clock.Advance(time.Second)
+	if err := <-done; err != nil {
+		t.Errorf("Wait() = %v; want nil", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { done <- l.Wait(ctx) }()
+	clock.BlockUntil(1)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Wait(canceled) = %v; want context.Canceled", err)
+	}
+	// 取消的Wait归还了预留的令牌
+	clock.Advance(time.Second)
+	if !l.Allow() || l.Allow() {
+		t.Errorf("Allow() after canceled Wait should pass exactly 1 request")
+	}
+
+	ctx, cancel = context.WithDeadline(context.Background(), clock.Now().Add(-time.Second))
+	defer cancel()
+	if err := l.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait(expired) = %v; want context.DeadlineExceeded", err)
+	}
+	if err := l.WaitN(context.Background(), 2); !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("WaitN(2) = %v; want ErrLimitExceeded", err)
+	}
+}
+
+func TestReservationCancel(t *testing.T) {
+	clock := newStepClock(fixedTime(getTestTimezone()))
+	l := NewTokenBucket(time.Second, 1, clock)
+	l.Allow()
+
+	l.Reserve().Cancel()
+	clock.Advance(time.Second)
+	if !l.Allow() || l.Allow() {
+		t.Errorf("Allow() after Cancel should pass exactly 1 request")
+	}
+
+	// 已到执行时间的预留不归还
+	r := l.Reserve()
+	clock.Advance(1500 * time.Millisecond)
+	r.Cancel()
+	if l.Allow() {
+		t.Errorf("Allow() after canceling an acted reservation = true; want false")
+	}
+	l.ReserveN(2).Cancel()
+	Reservation{}.Cancel()
+}
+
+func TestKeyedLimiter(t *testing.T) {
+	clock := newStepClock(fixedTime(getTestTimezone()))
+	k := NewKeyedLimiter[string](func() Limiter {
+		return NewTokenBucket(time.Minute, 1, clock)
+	}, 10*time.Minute, clock)
+
+	if !k.Allow("a") || k.Allow("a") || !k.Allow("b") {
+		t.Errorf("KeyedLimiter should limit keys independently")
+	}
+	clock.Advance(5 * time.Minute)
+	k.Allow("b")
+	clock.Advance(5 * time.Minute)
+	k.Allow("c")
+	if got := k.Len(); got != 2 {
+		t.Errorf("Len() after eviction = %d; want 2", got)
+	}
+	k.Remove("b")
+	if got := k.Len(); got != 1 {
+		t.Errorf("Len() after Remove = %d; want 1", got)
+	}
+
+	// 空闲超过idleTTL但窗口内的配额未恢复时不清理
+	loc := getTestTimezone()
+	clock = newStepClock(time.Date(2024, 7, 28, 10, 0, 0, 0, loc))
+	k = NewKeyedLimiter[string](func() Limiter {
+		return NewFixedWindow(1, PeriodOf(time.Hour), loc, clock)
+	}, 5*time.Minute, clock)
+	k.Allow("a")
+	k.Allow("b")
+	clock.Advance(10 * time.Minute)
+	if k.Allow("a") || k.Len() != 2 {
+		t.Errorf("Allow() after idleTTL in the same hour = true or key evicted; want false and kept")
+	}
+	clock.Advance(time.Hour)
+	k.Allow("c")
+	if got := k.Len(); got != 1 {
+		t.Errorf("Len() after the hour ended = %d; want 1", got)
+	}
+}
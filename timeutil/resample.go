@@ -0,0 +1,152 @@
+package timeutil
+
+import (
+	"sort"
+	"time"
+)
+
+// Point 时间序列中的一个点
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Aggregation 桶内数据的聚合方式
+type Aggregation int
+
+const (
+	AggSum   Aggregation = iota // 求和
+	AggAvg                      // 平均值
+	AggMin                      // 最小值
+	AggMax                      // 最大值
+	AggCount                    // 点数
+	AggLast                     // 时间最晚的值
+)
+
+// FillPolicy 空桶的填充方式
+type FillPolicy int
+
+const (
+	FillNone     FillPolicy = iota // 不填充，空桶不输出
+	FillZero                       // 填充0
+	FillPrevious                   // 填充前一个非空桶的值
+	FillLinear                     // 按前后两个非空桶线性插值
+)
+
+// ResampleConfig 重采样配置
+type ResampleConfig struct {
+	Period      Period         // 桶粒度
+	Aggregation Aggregation    // 聚合方式
+	Fill        FillPolicy     // 空桶填充方式
+	Timezone    *time.Location // 日、周、月等粒度按该时区划分，默认UTC
+	From        time.Time      // 输出的起始时间，零值时取第一个点
+	To          time.Time      // 输出的结束时间(包含所在桶)，零值时取最后一个点
+}
+
+// Resample 将时间序列按固定粒度重采样，返回以桶开始时间为Time的点。
+// FillPrevious、FillLinear无法填充的首尾空桶不会输出
+func Resample(points []Point, cfg ResampleConfig) []Point {
+	if cfg.Period.IsZero() {
+		return nil
+	}
+	timezone := cfg.Timezone
+	if timezone == nil {
+		timezone = TimezoneUtc
+	}
+
+	sorted := make([]Point, len(points))
+	copy(sorted, points)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Time.Before(sorted[j].Time)
+	})
+
+	from, to := cfg.From, cfg.To
+	if len(sorted) > 0 {
+		if from.IsZero() {
+			from = sorted[0].Time
+		}
+		if to.IsZero() {
+			to = sorted[len(sorted)-1].Time
+		}
+	}
+	if from.IsZero() || to.IsZero() || to.Before(from) {
+		return nil
+	}
+
+	type bucket struct {
+		start time.Time
+		value float64
+		count int
+	}
+	var buckets []bucket
+	idx := 0
+	for start := cfg.Period.Start(from, timezone); !start.After(to); start = cfg.Period.Next(start, timezone) {
+		end := cfg.Period.Next(start, timezone)
+		b := bucket{start: start}
+		for idx < len(sorted) && sorted[idx].Time.Before(start) {
+			idx++
+		}
+		for ; idx < len(sorted) && sorted[idx].Time.Before(end); idx++ {
+			b.value = aggregate(cfg.Aggregation, b.value, b.count, sorted[idx].Value)
+			b.count++
+		}
+		if b.count > 0 && cfg.Aggregation == AggAvg {
+			b.value /= float64(b.count)
+		}
+		if cfg.Aggregation == AggCount {
+			b.value = float64(b.count)
+		}
+		buckets = append(buckets, b)
+	}
+
+	var ret []Point
+	prev := -1
+	for i, b := range buckets {
+		if b.count > 0 || cfg.Aggregation == AggCount {
+			ret = append(ret, Point{Time: b.start, Value: b.value})
+			prev = i
+			continue
+		}
+		switch cfg.Fill {
+		case FillZero:
+			ret = append(ret, Point{Time: b.start})
+		case FillPrevious:
+			if prev >= 0 {
+				ret = append(ret, Point{Time: b.start, Value: buckets[prev].value})
+			}
+		case FillLinear:
+			next := i + 1
+			for next < len(buckets) && buckets[next].count == 0 {
+				next++
+			}
+			if prev >= 0 && next < len(buckets) {
+				p, n := buckets[prev], buckets[next]
+				ratio := float64(b.start.Sub(p.start)) / float64(n.start.Sub(p.start))
+				ret = append(ret, Point{Time: b.start, Value: p.value + (n.value-p.value)*ratio})
+			}
+		}
+	}
+	return ret
+}
+
+// aggregate 将value并入已有count个点的聚合结果acc
+func aggregate(agg Aggregation, acc float64, count int, value float64) float64 {
+	if count == 0 {
+		return value
+	}
+	switch agg {
+	case AggMin:
+		if value < acc {
+			return value
+		}
+		return acc
+	case AggMax:
+		if value > acc {
+			return value
+		}
+		return acc
+	case AggLast:
+		return value
+	}
+	return acc + value
+}
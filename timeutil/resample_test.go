@@ -0,0 +1,72 @@
+package timeutil
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResample(t *testing.T) {
+	loc := getTestTimezone()
+	at := func(minute, second int) time.Time {
+		return time.Date(2024, 7, 28, 10, minute, second, 0, loc)
+	}
+	points := []Point{
+		{at(1, 0), 1},
+		{at(0, 0), 3},
+		{at(4, 59), 2},
+		{at(16, 0), 9},
+	}
+	bucket := func(minute int, value float64) Point {
+		return Point{Time: at(minute, 0), Value: value}
+	}
+
+	tests := []struct {
+		name     string
+		cfg      ResampleConfig
+		expected []Point
+	}{
+		{"sum no fill", ResampleConfig{Period: PeriodOf(5 * time.Minute), Aggregation: AggSum, Timezone: loc},
+			[]Point{bucket(0, 6), bucket(15, 9)}},
+		{"avg zero fill", ResampleConfig{Period: PeriodOf(5 * time.Minute), Aggregation: AggAvg, Fill: FillZero, Timezone: loc},
+			[]Point{bucket(0, 2), bucket(5, 0), bucket(10, 0), bucket(15, 9)}},
+		{"min previous", ResampleConfig{Period: PeriodOf(5 * time.Minute), Aggregation: AggMin, Fill: FillPrevious, Timezone: loc},
+			[]Point{bucket(0, 1), bucket(5, 1), bucket(10, 1), bucket(15, 9)}},
+		{"max linear", ResampleConfig{Period: PeriodOf(5 * time.Minute), Aggregation: AggMax, Fill: FillLinear, Timezone: loc},
+			[]Point{bucket(0, 3), bucket(5, 5), bucket(10, 7), bucket(15, 9)}},
+		{"last", ResampleConfig{Period: PeriodOf(15 * time.Minute), Aggregation: AggLast, Timezone: loc},
+			[]Point{bucket(0, 2), bucket(15, 9)}},
+		{"count with bounds", ResampleConfig{Period: PeriodOf(10 * time.Minute), Aggregation: AggCount, Timezone: loc, To: at(25, 0)},
+			[]Point{bucket(0, 3), bucket(10, 1), bucket(20, 0)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Resample(points, tt.cfg); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Resample() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResampleDaily(t *testing.T) {
+	la := TimezoneLa
+	points := []Point{
+		{time.Date(2024, 3, 9, 23, 0, 0, 0, la), 1},
+		{time.Date(2024, 3, 10, 23, 30, 0, 0, la), 2},
+		{time.Date(2024, 3, 11, 0, 30, 0, 0, la), 4},
+	}
+	expected := []Point{
+		{time.Date(2024, 3, 9, 0, 0, 0, 0, la), 1},
+		{time.Date(2024, 3, 10, 0, 0, 0, 0, la), 2},
+		{time.Date(2024, 3, 11, 0, 0, 0, 0, la), 4},
+	}
+	got := Resample(points, ResampleConfig{Period: DailyPeriod(), Aggregation: AggSum, Timezone: la})
+	if len(got) != len(expected) {
+		t.Fatalf("Resample() = %v, want %v", got, expected)
+	}
+	for i := range got {
+		if !got[i].Time.Equal(expected[i].Time) || got[i].Value != expected[i].Value {
+			t.Errorf("Resample()[%d] = %v, want %v", i, got[i], expected[i])
+		}
+	}
+}
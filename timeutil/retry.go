@@ -0,0 +1,192 @@
+package timeutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Backoff 重试退避策略，Next返回第attempt次(从1开始)失败后的等待时长，last为上一次的等待时长
+type Backoff interface {
+	Next(attempt int, last time.Duration) time.Duration
+}
+
+// BackoffFunc 函数形式的Backoff
+type BackoffFunc func(attempt int, last time.Duration) time.Duration
+
+// Next 调用f(attempt, last)
+func (f BackoffFunc) Next(attempt int, last time.Duration) time.Duration {
+	return f(attempt, last)
+}
+
+// ConstantBackoff 固定等待时长
+func ConstantBackoff(d time.Duration) Backoff {
+	return BackoffFunc(func(int, time.Duration) time.Duration {
+		return d
+	})
+}
+
+// LinearBackoff 线性增长的等待时长 initial + (attempt-1)*step，max>0时不超过max
+func LinearBackoff(initial, step, max time.Duration) Backoff {
+	return BackoffFunc(func(attempt int, _ time.Duration) time.Duration {
+		return capDuration(initial+time.Duration(attempt-1)*step, max)
+	})
+}
+
+// ExponentialBackoff 指数增长的等待时长 initial * multiplier^(attempt-1)，max>0时不超过max
+func ExponentialBackoff(initial time.Duration, multiplier float64, max time.Duration) Backoff {
+	return BackoffFunc(func(attempt int, _ time.Duration) time.Duration {
+		d := float64(initial)
+		for i := 1; i < attempt; i++ {
+			d *= multiplier
+			if max > 0 && d >= float64(max) {
+				return max
+			}
+		}
+		return capDuration(time.Duration(d), max)
+	})
+}
+
+// FibonacciBackoff 按斐波那契数列增长的等待时长 unit, unit, 2*unit, 3*unit, 5*unit...，max>0时不超过max
+func FibonacciBackoff(unit, max time.Duration) Backoff {
+	return BackoffFunc(func(attempt int, _ time.Duration) time.Duration {
+		a, b := unit, unit
+		for i := 1; i < attempt; i++ {
+			a, b = b, a+b
+			if max > 0 && a >= max {
+				return max
+			}
+		}
+		return capDuration(a, max)
+	})
+}
+
+// DecorrelatedJitterBackoff 去相关抖动退避，等待时长在[base, last*3)之间随机，不超过max
+func DecorrelatedJitterBackoff(base, max time.Duration) Backoff {
+	return BackoffFunc(func(_ int, last time.Duration) time.Duration {
+		if last < base {
+			last = base
+		}
+		upper := last * 3
+		if upper <= base {
+			return capDuration(base, max)
+		}
+		return capDuration(base+time.Duration(rand.Int63n(int64(upper-base))), max)
+	})
+}
+
+// WithJitter 为退避策略增加随机抖动，等待时长在 d*(1-factor) 到 d*(1+factor) 之间
+func WithJitter(backoff Backoff, factor float64) Backoff {
+	return BackoffFunc(func(attempt int, last time.Duration) time.Duration {
+		d := backoff.Next(attempt, last)
+		if factor <= 0 || d <= 0 {
+			return d
+		}
+		delta := float64(d) * factor
+		return time.Duration(float64(d) - delta + rand.Float64()*2*delta)
+	})
+}
+
+func capDuration(d, max time.Duration) time.Duration {
+	if max > 0 && d > max {
+		return max
+	}
+	return d
+}
+
+// permanentError 不可重试的错误
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent 将err标记为不可重试，Retry遇到时立即返回err
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent err是否被标记为不可重试
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// Attempt 一次执行的记录
+type Attempt struct {
+	Number   int           // 第几次执行，从1开始
+	Start    time.Time     // 开始时间
+	Duration time.Duration // 执行耗时
+	Err      error         // 执行结果
+	Delay    time.Duration // 执行失败后计划的等待时长，不再重试时为0
+}
+
+// RetryPolicy 重试策略
+type RetryPolicy struct {
+	Backoff     Backoff              // 退避策略，nil时不等待
+	MaxAttempts int                  // 最大执行次数，<=0时不限
+	MaxElapsed  time.Duration        // 从首次执行开始的最长总时长，下一次执行会超出时不再重试，<=0时不限
+	Retryable   func(err error) bool // 判断错误是否可重试，nil时除Permanent外均可重试
+	OnAttempt   func(a Attempt)      // 每次执行结束后的回调
+	Clock       Clock                // 时间源，nil时使用SystemClock
+}
+
+// Retry 按policy执行fn直到成功、遇到不可重试的错误、达到次数或时长上限、ctx结束，返回每次执行的记录。
+// 失败时返回最后一次的错误(已去掉Permanent标记)，ctx结束时返回的错误同时包含ctx.Err()
+func Retry(ctx context.Context, policy RetryPolicy, fn func(ctx context.Context) error) ([]Attempt, error) {
+	clock := orSystemClock(policy.Clock)
+	start := clock.Now()
+	var attempts []Attempt
+	var delay time.Duration
+
+	for number := 1; ; number++ {
+		if err := ctx.Err(); err != nil {
+			return attempts, err
+		}
+		a := Attempt{Number: number, Start: clock.Now()}
+		a.Err = fn(ctx)
+		a.Duration = clock.Since(a.Start)
+
+		retry := a.Err != nil && !IsPermanent(a.Err) &&
+			(policy.Retryable == nil || policy.Retryable(a.Err)) &&
+			(policy.MaxAttempts <= 0 || number < policy.MaxAttempts)
+		if retry && policy.Backoff != nil {
+			delay = policy.Backoff.Next(number, delay)
+		}
+		if retry && policy.MaxElapsed > 0 && clock.Since(start)+delay >= policy.MaxElapsed {
+			retry = false
+		}
+		if retry {
+			a.Delay = delay
+		}
+		attempts = append(attempts, a)
+		if policy.OnAttempt != nil {
+			policy.OnAttempt(a)
+		}
+
+		if a.Err == nil {
+			return attempts, nil
+		}
+		var p *permanentError
+		if errors.As(a.Err, &p) {
+			return attempts, p.err
+		}
+		if !retry {
+			return attempts, a.Err
+		}
+		if err := sleepContext(ctx, clock, a.Delay); err != nil {
+			return attempts, fmt.Errorf("%w: %w", err, a.Err)
+		}
+	}
+}
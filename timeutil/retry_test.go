@@ -0,0 +1,138 @@
+package timeutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		backoff  Backoff
+		expected []time.Duration
+	}{
+		{"constant", ConstantBackoff(time.Second), []time.Duration{time.Second, time.Second, time.Second}},
+		{"linear", LinearBackoff(time.Second, 2*time.Second, 4*time.Second), []time.Duration{time.Second, 3 * time.Second, 4 * time.Second}},
+		{"exponential", ExponentialBackoff(time.Second, 2, 5*time.Second), []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}},
+		{"fibonacci", FibonacciBackoff(time.Second, 0), []time.Duration{time.Second, time.Second, 2 * time.Second, 3 * time.Second, 5 * time.Second}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var last time.Duration
+			for i, want := range tt.expected {
+				last = tt.backoff.Next(i+1, last)
+				if last != want {
+					t.Errorf("Next(%d) = %v; want %v", i+1, last, want)
+				}
+			}
+		})
+	}
+}
+
+func TestJitterBackoff(t *testing.T) {
+	decorrelated := DecorrelatedJitterBackoff(time.Second, 10*time.Second)
+	jitter := WithJitter(ConstantBackoff(10*time.Second), 0.2)
+	var last time.Duration
+	for i := 1; i <= 100; i++ {
+		last = decorrelated.Next(i, last)
+		if last < time.Second || last > 10*time.Second {
+			t.Fatalf("DecorrelatedJitterBackoff.Next(%d) = %v; want within [1s, 10s]", i, last)
+		}
+		if d := jitter.Next(i, 0); d < 8*time.Second || d > 12*time.Second {
+			t.Fatalf("WithJitter.Next(%d) = %v; want within [8s, 12s]", i, d)
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	errTemporary := errors.New("temporary")
+	errFatal := errors.New("fatal")
+
+	t.Run("success after retries", func(t *testing.T) {
+		clock := newStepClock(fixedTime(getTestTimezone()))
+		calls := 0
+		attempts, err := Retry(context.Background(), RetryPolicy{Clock: clock, Backoff: ConstantBackoff(time.Second), MaxAttempts: 5},
+			func(ctx context.Context) error {
+				calls++
+				clock.Advance(100 * time.Millisecond)
+				if calls < 3 {
+					return errTemporary
+				}
+				return nil
+			})
+		if err != nil || len(attempts) != 3 {
+			t.Fatalf("Retry() = %d attempts, %v; want 3, nil", len(attempts), err)
+		}
+		if attempts[0].Delay != time.Second || attempts[0].Duration != 100*time.Millisecond || attempts[2].Delay != 0 {
+			t.Errorf("attempts = %+v", attempts)
+		}
+		if gap := attempts[1].Start.Sub(attempts[0].Start); gap != 1100*time.Millisecond {
+			t.Errorf("gap between attempts = %v; want 1.1s", gap)
+		}
+	})
+
+	t.Run("max attempts", func(t *testing.T) {
+		clock := newStepClock(fixedTime(getTestTimezone()))
+		attempts, err := Retry(context.Background(), RetryPolicy{Clock: clock, Backoff: ExponentialBackoff(time.Second, 2, 0), MaxAttempts: 3},
+			func(ctx context.Context) error { return errTemporary })
+		if !errors.Is(err, errTemporary) || len(attempts) != 3 {
+			t.Errorf("Retry() = %d attempts, %v; want 3, temporary", len(attempts), err)
+		}
+	})
+
+	t.Run("max elapsed", func(t *testing.T) {
+		clock := newStepClock(fixedTime(getTestTimezone()))
+		attempts, err := Retry(context.Background(), RetryPolicy{Clock: clock, Backoff: ConstantBackoff(4 * time.Second), MaxElapsed: 10 * time.Second},
+			func(ctx context.Context) error { return errTemporary })
+		if !errors.Is(err, errTemporary) || len(attempts) != 3 {
+			t.Errorf("Retry() = %d attempts, %v; want 3, temporary", len(attempts), err)
+		}
+	})
+
+	t.Run("not retryable", func(t *testing.T) {
+		clock := newStepClock(fixedTime(getTestTimezone()))
+		attempts, err := Retry(context.Background(), RetryPolicy{
+			Clock:     clock,
+			Backoff:   ConstantBackoff(time.Second),
+			Retryable: func(err error) bool { return !errors.Is(err, errFatal) },
+		}, func(ctx context.Context) error { return errFatal })
+		if err != errFatal || len(attempts) != 1 {
+			t.Errorf("Retry() = %d attempts, %v; want 1, fatal", len(attempts), err)
+		}
+	})
+
+	t.Run("permanent", func(t *testing.T) {
+		clock := newStepClock(fixedTime(getTestTimezone()))
+		var reported []Attempt
+		attempts, err := Retry(context.Background(), RetryPolicy{Clock: clock, OnAttempt: func(a Attempt) { reported = append(reported, a) }},
+			func(ctx context.Context) error { return Permanent(errFatal) })
+		if err != errFatal || len(attempts) != 1 || len(reported) != 1 {
+			t.Errorf("Retry() = %d attempts, %v; want 1, fatal", len(attempts), err)
+		}
+	})
+
+	t.Run("context canceled", func(t *testing.T) {
+		clock := newStepClock(fixedTime(getTestTimezone()))
+		ctx, cancel := context.WithCancel(context.Background())
+		attempts, err := Retry(ctx, RetryPolicy{Backoff: ConstantBackoff(time.Hour), Clock: clock},
+			func(ctx context.Context) error {
+				cancel()
+				return errTemporary
+			})
+		if !errors.Is(err, context.Canceled) || len(attempts) != 1 {
+			t.Errorf("Retry() = %d attempts, %v; want 1, context.Canceled", len(attempts), err)
+		}
+	})
+}
+
+func TestPermanent(t *testing.T) {
+	if Permanent(nil) != nil {
+		t.Errorf("Permanent(nil) != nil")
+	}
+	err := errors.New("x")
+	if !IsPermanent(Permanent(err)) || IsPermanent(err) || !errors.Is(Permanent(err), err) {
+		t.Errorf("Permanent(%v) not detected correctly", err)
+	}
+}
@@ -0,0 +1,142 @@
+package timeutil
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ShiftTemplate 班次模板，例如白班08:00开始12小时
+type ShiftTemplate struct {
+	Name  string // 班次名称
+	Start string // 当地开始时间，格式HH:MM
+	// Duration 按墙上时间计算的班次时长，可以跨零点；夏令时切换日的班次仍在墙上时间Start+Duration结束，实际时长相应增减
+	Duration time.Duration
+}
+
+// Shift 生成的具体班次
+type Shift struct {
+	Person   string   // 值班人
+	Name     string   // 班次名称
+	Interval Interval // 值班时间 [开始, 结束)
+}
+
+// Rota 轮班表。Anchor所在日由People[0]值Shifts[0]、People[1]值Shifts[1]……，
+// 每RotateEvery天轮换一次，轮换时人员顺序前进Step位。例如：
+//   - 两人白班夜班每周互换：Shifts为白班、夜班，RotateEvery为7，Step为1
+//   - 四班组做四休四：People为A、B、C、D，Shifts为白班、夜班，RotateEvery为4，Step为0(A、B值班后由C、D接班)
+//   - 每周一人值班：Shifts为一个24小时的班次，RotateEvery为7，Step为0
+type Rota struct {
+	People      []string        // 参与轮班的人员或班组
+	Shifts      []ShiftTemplate // 每天的班次
+	Timezone    *time.Location  // 班次开始时间所在的时区，为nil时为UTC
+	Anchor      time.Time       // 轮换起点，按Timezone取所在日
+	RotateEvery int             // 每隔多少天轮换一次，不大于0时为每天
+	Step        int             // 每次轮换人员前进的位数，为0时为len(Shifts)，即由下一组人接班
+}
+
+// Validate 检查轮班表的配置，Generate和OnShift会忽略不合法的班次
+func (r Rota) Validate() error {
+	if len(r.People) == 0 {
+		return fmt.Errorf("timeutil: rota has no people")
+	}
+	if len(r.Shifts) == 0 {
+		return fmt.Errorf("timeutil: rota has no shifts")
+	}
+	for _, s := range r.Shifts {
+		if start, err := parseClockTime(s.Start); err != nil || start >= 24*time.Hour {
+			return fmt.Errorf("timeutil: shift %q has invalid start %q", s.Name, s.Start)
+		}
+		if s.Duration <= 0 {
+			return fmt.Errorf("timeutil: shift %q has non-positive duration %v", s.Name, s.Duration)
+		}
+	}
+	return nil
+}
+
+func (r Rota) timezone() *time.Location {
+	if r.Timezone == nil {
+		return TimezoneUtc
+	}
+	return r.Timezone
+}
+
+// Generate 生成开始于日期范围内的所有班次，按开始时间排序
+func (r Rota) Generate(dates DateRange) []Shift {
+	var shifts []Shift
+	for day := dates.Start; !day.After(dates.End); day = day.AddDate(0, 0, 1) {
+		shifts = append(shifts, r.shiftsOn(day)...)
+	}
+	sort.SliceStable(shifts, func(i, j int) bool { return shifts[i].Interval.Start.Before(shifts[j].Interval.Start) })
+	return shifts
+}
+
+// OnShift t时正在值班的班次，可能有多个(例如班次重叠或同时有多个岗位)
+func (r Rota) OnShift(t time.Time) []Shift {
+	var longest time.Duration
+	for _, s := range r.Shifts {
+		if s.Duration > longest {
+			longest = s.Duration
+		}
+	}
+	day := StartOfDay(t, r.timezone())
+	var ret []Shift
+	for i := int(longest/(24*time.Hour)) + 1; i >= 0; i-- {
+		for _, s := range r.shiftsOn(day.AddDate(0, 0, -i)) {
+			if s.Interval.Contains(t) {
+				ret = append(ret, s)
+			}
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool { return ret[i].Interval.Start.Before(ret[j].Interval.Start) })
+	return ret
+}
+
+// shiftsOn day所在日开始的班次
+func (r Rota) shiftsOn(day time.Time) []Shift {
+	if len(r.People) == 0 {
+		return nil
+	}
+	timezone := r.timezone()
+	y, m, d := day.In(timezone).Date()
+	ay, am, ad := r.Anchor.In(timezone).Date()
+	every := r.RotateEvery
+	if every <= 0 {
+		every = 1
+	}
+	step := r.Step
+	if step == 0 {
+		step = len(r.Shifts)
+	}
+	block := floorDiv(civilDays(y, m, d)-civilDays(ay, am, ad), every)
+
+	var shifts []Shift
+	for i, tpl := range r.Shifts {
+		start, err := parseClockTime(tpl.Start)
+		if err != nil || start >= 24*time.Hour || tpl.Duration <= 0 {
+			continue
+		}
+		person := r.People[floorMod(block*step+i, len(r.People))]
+		shifts = append(shifts, Shift{
+			Person: person,
+			Name:   tpl.Name,
+			Interval: Interval{
+				Start: time.Date(y, m, d, 0, 0, 0, int(start), timezone),
+				End:   time.Date(y, m, d, 0, 0, 0, int(start+tpl.Duration), timezone),
+			},
+		})
+	}
+	return shifts
+}
+
+func floorDiv(a, b int) int {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+func floorMod(a, b int) int {
+	return a - floorDiv(a, b)*b
+}
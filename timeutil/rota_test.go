@@ -0,0 +1,95 @@
+package timeutil
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func formatShifts(shifts []Shift, timezone *time.Location) string {
+	var ret []string
+	for _, s := range shifts {
+		ret = append(ret, fmt.Sprintf("%s %s %s-%s", s.Interval.Start.In(timezone).Format("0102"), s.Name, s.Person,
+			s.Interval.End.In(timezone).Format("0102T15")))
+	}
+	return strings.Join(ret, ",")
+}
+
+func TestRotaGenerate(t *testing.T) {
+	loc := getTestTimezone()
+	dayNight := []ShiftTemplate{{Name: "D", Start: "08:00", Duration: 12 * time.Hour}, {Name: "N", Start: "20:00", Duration: 12 * time.Hour}}
+	tests := []struct {
+		name     string
+		rota     Rota
+		expected string
+	}{
+		{
+			"4-on-4-off",
+			Rota{People: []string{"A", "B", "C", "D"}, Shifts: dayNight, Timezone: loc, Anchor: testDate(2024, 7, 1), RotateEvery: 4},
+			"0703 D A-0703T20,0703 N B-0704T08,0704 D A-0704T20,0704 N B-0705T08,0705 D C-0705T20,0705 N D-0706T08",
+		},
+		{
+			"weekly swap",
+			Rota{People: []string{"A", "B"}, Shifts: dayNight, Timezone: loc, Anchor: testDate(2024, 7, 1), RotateEvery: 7, Step: 1},
+			"0703 D A-0703T20,0703 N B-0704T08,0704 D A-0704T20,0704 N B-0705T08,0705 D A-0705T20,0705 N B-0706T08",
+		},
+		{
+			"before anchor",
+			Rota{People: []string{"A", "B", "C"}, Shifts: dayNight[:1], Timezone: loc, Anchor: testDate(2024, 7, 5)},
+			"0703 D B-0703T20,0704 D C-0704T20,0705 D A-0705T20",
+		},
+	}
+	for _, test := range tests {
+		if err := test.rota.Validate(); err != nil {
+			t.Fatalf("%s: Validate() = %v", test.name, err)
+		}
+		got := formatShifts(test.rota.Generate(NewDateRangeBiDay("20240703", "20240705", loc)), loc)
+		if got != test.expected {
+			t.Errorf("%s: Generate() = %s; want %s", test.name, got, test.expected)
+		}
+	}
+}
+
+func TestRotaOnShift(t *testing.T) {
+	loc := TimezoneNy
+	r := Rota{
+		People:      []string{"A", "B"},
+		Shifts:      []ShiftTemplate{{Name: "D", Start: "08:00", Duration: 12 * time.Hour}, {Name: "N", Start: "20:00", Duration: 12 * time.Hour}},
+		Timezone:    loc,
+		Anchor:      time.Date(2024, 11, 1, 0, 0, 0, 0, loc),
+		RotateEvery: 7,
+		Step:        1,
+	}
+	// 2024-11-03 美国夏令时结束，前一晚的夜班按墙上时间08:00结束，实际13小时
+	shifts := r.OnShift(time.Date(2024, 11, 3, 7, 30, 0, 0, loc))
+	if len(shifts) != 1 || shifts[0].Name != "N" || shifts[0].Person != "B" || shifts[0].Interval.Duration() != 13*time.Hour {
+		t.Errorf("OnShift(DST end) = %v", shifts)
+	}
+	shifts = r.OnShift(time.Date(2024, 11, 3, 8, 0, 0, 0, loc))
+	if len(shifts) != 1 || shifts[0].Name != "D" || shifts[0].Person != "A" {
+		t.Errorf("OnShift(08:00) = %v", shifts)
+	}
+
+	weekly := Rota{People: []string{"A", "B", "C"}, Shifts: []ShiftTemplate{{Name: "on-call", Start: "09:00", Duration: 24 * time.Hour}}, Anchor: time.Date(2024, 7, 1, 0, 0, 0, 0, TimezoneUtc), RotateEvery: 7}
+	for tm, person := range map[time.Time]string{
+		time.Date(2024, 7, 8, 8, 59, 0, 0, TimezoneUtc):  "A",
+		time.Date(2024, 7, 8, 9, 0, 0, 0, TimezoneUtc):   "B",
+		time.Date(2024, 7, 25, 12, 0, 0, 0, TimezoneUtc): "A",
+	} {
+		if shifts := weekly.OnShift(tm); len(shifts) != 1 || shifts[0].Person != person {
+			t.Errorf("OnShift(%v) = %v; want %s", tm, shifts, person)
+		}
+	}
+
+	for _, bad := range []Rota{
+		{Shifts: r.Shifts},
+		{People: r.People},
+		{People: r.People, Shifts: []ShiftTemplate{{Name: "X", Start: "24:00", Duration: time.Hour}}},
+		{People: r.People, Shifts: []ShiftTemplate{{Name: "X", Start: "08:00"}}},
+	} {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil; want error", bad)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package timeutil
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Lap 秒表的一次计圈
+type Lap struct {
+	Name     string
+	Duration time.Duration // 距上一次计圈的时长
+	Elapsed  time.Duration // 距秒表启动的时长
+}
+
+// Stopwatch 秒表，基于单调时钟计时，可以并发使用
+type Stopwatch struct {
+	mu    sync.Mutex
+	clock Clock
+	start time.Time
+	last  time.Time
+	laps  []Lap
+}
+
+// NewStopwatch 创建并启动秒表，clock为nil时使用SystemClock
+func NewStopwatch(clock Clock) *Stopwatch {
+	s := &Stopwatch{clock: orSystemClock(clock)}
+	s.Reset()
+	return s
+}
+
+// Lap 记录一次计圈，返回距上一次计圈的时长
+func (s *Stopwatch) Lap(name string) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.clock.Now()
+	lap := Lap{Name: name, Duration: now.Sub(s.last), Elapsed: now.Sub(s.start)}
+	s.laps = append(s.laps, lap)
+	s.last = now
+	return lap.Duration
+}
+
+// Elapsed 距秒表启动的时长
+func (s *Stopwatch) Elapsed() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.clock.Since(s.start)
+}
+
+// Laps 返回所有计圈记录的副本
+func (s *Stopwatch) Laps() []Lap {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ret := make([]Lap, len(s.laps))
+	copy(ret, s.laps)
+	return ret
+}
+
+// Reset 清空计圈记录并重新开始计时
+func (s *Stopwatch) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.start = s.clock.Now()
+	s.last = s.start
+	s.laps = nil
+}
+
+// Span 一次被计时的代码段
+type Span struct {
+	Name     string
+	Path     string // 包含父级的完整路径，例如"handler/db"
+	Depth    int    // 嵌套深度，顶层为0
+	Start    time.Time
+	Duration time.Duration
+}
+
+// SpanSink 代码段计时结果的接收方
+type SpanSink interface {
+	RecordSpan(span Span)
+}
+
+// SpanSinkFunc 函数形式的SpanSink
+type SpanSinkFunc func(span Span)
+
+// RecordSpan 调用f(span)
+func (f SpanSinkFunc) RecordSpan(span Span) {
+	f(span)
+}
+
+// SlogSpanSink 将计时结果写入结构化日志
+func SlogSpanSink(logger *slog.Logger, level slog.Level) SpanSink {
+	return SpanSinkFunc(func(span Span) {
+		logger.LogAttrs(context.Background(), level, "span finished",
+			slog.String("span", span.Path),
+			slog.Duration("duration", span.Duration),
+		)
+	})
+}
+
+// HistogramSpanSink 将计时结果以秒为单位上报到直方图，observe通常包装prometheus的HistogramVec
+func HistogramSpanSink(observe func(path string, seconds float64)) SpanSink {
+	return SpanSinkFunc(func(span Span) {
+		observe(span.Path, span.Duration.Seconds())
+	})
+}
+
+// SpanStats 同一路径代码段的聚合统计，分位数基于最近的采样
+type SpanStats struct {
+	Count int
+	Total time.Duration
+	Min   time.Duration
+	Max   time.Duration
+	Mean  time.Duration
+	P50   time.Duration
+	P95   time.Duration
+	P99   time.Duration
+}
+
+// profilerSampleSize 每个路径保留用于计算分位数的最近采样数
+const profilerSampleSize = 1024
+
+type spanStats struct {
+	count    int
+	total    time.Duration
+	min, max time.Duration
+	samples  []time.Duration
+	next     int
+}
+
+type spanParentKey struct{}
+
+// spanParent ctx中记录的父级代码段，深度单独记录，name中可以包含"/"
+type spanParent struct {
+	path  string
+	depth int
+}
+
+// Profiler 代码段计时器，支持嵌套计时、多个结果接收方及聚合统计，可以并发使用
+type Profiler struct {
+	clock Clock
+	sinks []SpanSink
+	mu    sync.Mutex
+	stats map[string]*spanStats
+}
+
+// NewProfiler 创建代码段计时器，clock为nil时使用SystemClock
+func NewProfiler(clock Clock, sinks ...SpanSink) *Profiler {
+	return &Profiler{clock: orSystemClock(clock), sinks: sinks, stats: make(map[string]*spanStats)}
+}
+
+// Start 开始对name计时，返回的ctx传给子代码段即可形成嵌套路径；调用返回的函数结束计时并返回耗时
+func (p *Profiler) Start(ctx context.Context, name string) (context.Context, func() time.Duration) {
+	path := name
+	depth := 0
+	if parent, ok := ctx.Value(spanParentKey{}).(spanParent); ok {
+		path = parent.path + "/" + name
+		depth = parent.depth + 1
+	}
+	start := p.clock.Now()
+	var once sync.Once
+	var elapsed time.Duration
+	return context.WithValue(ctx, spanParentKey{}, spanParent{path: path, depth: depth}), func() time.Duration {
+		once.Do(func() {
+			elapsed = p.clock.Since(start)
+			p.record(Span{Name: name, Path: path, Depth: depth, Start: start, Duration: elapsed})
+		})
+		return elapsed
+	}
+}
+
+// Trace 对name计时，用法与TraceFuncTime相同："defer p.Trace("name")()"
+func (p *Profiler) Trace(name string) func() {
+	_, end := p.Start(context.Background(), name)
+	return func() {
+		end()
+	}
+}
+
+// Stats 获取某路径的聚合统计
+func (p *Profiler) Stats(path string) (SpanStats, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	s, ok := p.stats[path]
+	if !ok {
+		return SpanStats{}, false
+	}
+	return s.snapshot(), true
+}
+
+// AllStats 获取所有路径的聚合统计
+func (p *Profiler) AllStats() map[string]SpanStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	ret := make(map[string]SpanStats, len(p.stats))
+	for path, s := range p.stats {
+		ret[path] = s.snapshot()
+	}
+	return ret
+}
+
+// Reset 清空聚合统计
+func (p *Profiler) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.stats = make(map[string]*spanStats)
+}
+
+func (p *Profiler) record(span Span) {
+	p.mu.Lock()
+	s, ok := p.stats[span.Path]
+	if !ok {
+		s = &spanStats{min: span.Duration, max: span.Duration}
+		p.stats[span.Path] = s
+	}
+	s.add(span.Duration)
+	p.mu.Unlock()
+
+	for _, sink := range p.sinks {
+		sink.RecordSpan(span)
+	}
+}
+
+func (s *spanStats) add(d time.Duration) {
+	s.count++
+	s.total += d
+	if d < s.min {
+		s.min = d
+	}
+	if d > s.max {
+		s.max = d
+	}
+	if len(s.samples) < profilerSampleSize {
+		s.samples = append(s.samples, d)
+		return
+	}
+	s.samples[s.next] = d
+	s.next = (s.next + 1) % profilerSampleSize
+}
+
+func (s *spanStats) snapshot() SpanStats {
+	sorted := make([]time.Duration, len(s.samples))
+	copy(sorted, s.samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	percentile := func(p float64) time.Duration {
+		idx := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		return sorted[idx]
+	}
+	return SpanStats{
+		Count: s.count,
+		Total: s.total,
+		Min:   s.min,
+		Max:   s.max,
+		Mean:  s.total / time.Duration(s.count),
+		P50:   percentile(0.50),
+		P95:   percentile(0.95),
+		P99:   percentile(0.99),
+	}
+}
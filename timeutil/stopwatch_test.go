@@ -0,0 +1,122 @@
+package timeutil
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStopwatch(t *testing.T) {
+	clock := newStepClock(fixedTime(getTestTimezone()))
+	sw := NewStopwatch(clock)
+
+	clock.Advance(100 * time.Millisecond)
+	if got := sw.Lap("load"); got != 100*time.Millisecond {
+		t.Errorf("Lap(load) = %v; want 100ms", got)
+	}
+	clock.Advance(50 * time.Millisecond)
+	sw.Lap("parse")
+	clock.Advance(10 * time.Millisecond)
+
+	expected := []Lap{
+		{Name: "load", Duration: 100 * time.Millisecond, Elapsed: 100 * time.Millisecond},
+		{Name: "parse", Duration: 50 * time.Millisecond, Elapsed: 150 * time.Millisecond},
+	}
+	laps := sw.Laps()
+	if len(laps) != len(expected) || laps[0] != expected[0] || laps[1] != expected[1] {
+		t.Errorf("Laps() = %v; want %v", laps, expected)
+	}
+	if got := sw.Elapsed(); got != 160*time.Millisecond {
+		t.Errorf("Elapsed() = %v; want 160ms", got)
+	}
+
+	sw.Reset()
+	if got := sw.Elapsed(); got != 0 || len(sw.Laps()) != 0 {
+		t.Errorf("after Reset() Elapsed() = %v, Laps() = %v; want 0, []", got, sw.Laps())
+	}
+}
+
+func TestProfilerNestedSpans(t *testing.T) {
+	clock := newStepClock(fixedTime(getTestTimezone()))
+	var spans []Span
+	p := NewProfiler(clock, SpanSinkFunc(func(span Span) { spans = append(spans, span) }))
+
+	ctx, endHandler := p.Start(context.Background(), "handler")
+	clock.Advance(5 * time.Millisecond)
+	_, endDB := p.Start(ctx, "db")
+	clock.Advance(20 * time.Millisecond)
+	if got := endDB(); got != 20*time.Millisecond {
+		t.Errorf("endDB() = %v; want 20ms", got)
+	}
+	endDB() // 重复调用不会重复记录
+	endHandler()
+
+	if len(spans) != 2 {
+		t.Fatalf("recorded %d spans; want 2", len(spans))
+	}
+	if spans[0].Path != "handler/db" || spans[0].Depth != 1 || spans[1].Path != "handler" || spans[1].Duration != 25*time.Millisecond {
+		t.Errorf("recorded spans = %+v", spans)
+	}
+
+	// 名称中的"/"不影响嵌套深度
+	spans = nil
+	ctx, endRoute := p.Start(context.Background(), "GET /api/users")
+	_, endQuery := p.Start(ctx, "db")
+	endQuery()
+	endRoute()
+	if spans[0].Path != "GET /api/users/db" || spans[0].Depth != 1 || spans[1].Depth != 0 {
+		t.Errorf("recorded spans = %+v", spans)
+	}
+}
+
+func TestProfilerStats(t *testing.T) {
+	clock := newStepClock(fixedTime(getTestTimezone()))
+	p := NewProfiler(clock)
+	for i := 1; i <= 100; i++ {
+		func() {
+			defer p.Trace("query")()
+			clock.Advance(time.Duration(i) * time.Millisecond)
+		}()
+	}
+
+	stats, ok := p.Stats("query")
+	expected := SpanStats{
+		Count: 100,
+		Total: 5050 * time.Millisecond,
+		Min:   time.Millisecond,
+		Max:   100 * time.Millisecond,
+		Mean:  50500 * time.Microsecond,
+		P50:   50 * time.Millisecond,
+		P95:   95 * time.Millisecond,
+		P99:   99 * time.Millisecond,
+	}
+	if !ok || stats != expected {
+		t.Errorf("Stats(query) = %+v, %v; want %+v", stats, ok, expected)
+	}
+	if _, ok := p.Stats("missing"); ok {
+		t.Errorf("Stats(missing) ok = true; want false")
+	}
+	if all := p.AllStats(); len(all) != 1 {
+		t.Errorf("AllStats() = %v; want 1 entry", all)
+	}
+}
+
+func TestSpanSinks(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	var observed []string
+	p := NewProfiler(newStepClock(time.Time{}), SlogSpanSink(logger, slog.LevelInfo), HistogramSpanSink(func(path string, seconds float64) {
+		observed = append(observed, path)
+	}))
+	p.Trace("job")()
+
+	if !strings.Contains(buf.String(), "span=job") {
+		t.Errorf("slog output = %q; want span=job", buf.String())
+	}
+	if len(observed) != 1 || observed[0] != "job" {
+		t.Errorf("histogram observed %v; want [job]", observed)
+	}
+}
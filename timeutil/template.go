@@ -0,0 +1,236 @@
+package timeutil
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrTemplateVariable 无法展开的模板变量
+var ErrTemplateVariable = errors.New("timeutil: unknown template variable")
+
+var (
+	templatePlaceholderRe = regexp.MustCompile(`\$\{([^{}]*)\}|\$\[([^\[\]]*)\]|\{\{(.*?)\}\}`)
+	templateOffsetRe      = regexp.MustCompile(`^(.+?)\s*([+-])\s*(\d+)((?:\s*/\s*\d+)*)$`)
+	templateAddMonthsRe   = regexp.MustCompile(`^add_months\(\s*(.+?)\s*,\s*([+-]?\d+)\s*\)$`)
+	templateDsAddRe       = regexp.MustCompile(`^macros\.ds_add\(\s*(.+?)\s*,\s*([+-]?\d+)\s*\)$`)
+	templateDsFormatRe    = regexp.MustCompile(`^macros\.ds_format\(\s*(.+?)\s*,\s*['"](.*?)['"]\s*,\s*['"](.*?)['"]\s*\)$`)
+
+	// 内置变量，兼容Airflow与DataWorks
+	templateBuiltinLayouts = map[string]string{
+		"bizdate":   FormatYYYYMMDDNoSymbol,
+		"cyctime":   FormatYYYYMMDDHHMMSSNoSymbol,
+		"ds":        FormatYYYYMMDD,
+		"ds_nodash": FormatYYYYMMDDNoSymbol,
+		"ts":        "2006-01-02T15:04:05-07:00",
+		"ts_nodash": "20060102T150405",
+	}
+	templateBuiltinOffsets = map[string]int{
+		"yesterday_ds": -1, "yesterday_ds_nodash": -1, "tomorrow_ds": 1, "tomorrow_ds_nodash": 1,
+	}
+)
+
+// DateTemplate 日期模板，展开SQL、文件路径等配置中的日期占位符。支持以下写法：
+//   - ${yyyyMMdd}、${yyyy-MM-dd}、${HH}、$[yyyymmddhh24miss]：按Java/DataWorks风格的格式输出
+//   - ${bizdate-1}、${yyyyMMdd+7}、$[hh24-1/24]、$[yyyymmddhh24mi-30/24/60]：按天偏移，/24为小时，/24/60为分钟
+//   - $[add_months(yyyymmdd,-1)]：按月偏移
+//   - {{ds}}、{{ ds_nodash }}、{{ts}}、{{yesterday_ds}}、{{ macros.ds_add(ds, -7) }}、{{ macros.ds_format(ds, "%Y-%m-%d", "%Y%m%d") }}：兼容Airflow
+//
+// Base为调度的定时时间，即DataWorks的cyctime、Airflow的logical date。与DataWorks一致，${...}按业务日期bizdate
+// (Base的前一天)计算，$[...]和{{...}}按Base计算；变量名为bizdate、cyctime时不论括号类型分别按业务日期和Base计算，
+// 例如Base为2024-07-28 10:15:30时${bizdate}和${yyyyMMdd}为20240727，$[yyyymmdd]为20240728。
+// Vars中的自定义变量优先于内置变量
+type DateTemplate struct {
+	Base     time.Time
+	Timezone *time.Location
+	Vars     map[string]string
+}
+
+// ExpandDateTemplate 以base为定时时间展开模板中的日期占位符，${...}按base的前一天计算，见DateTemplate
+func ExpandDateTemplate(tpl string, base time.Time, timezone *time.Location) (string, error) {
+	return DateTemplate{Base: base, Timezone: timezone}.Expand(tpl)
+}
+
+// Expand 展开模板中的日期占位符，遇到无法识别的变量时返回ErrTemplateVariable
+func (d DateTemplate) Expand(tpl string) (string, error) {
+	timezone := d.Timezone
+	if timezone == nil {
+		timezone = TimezoneUtc
+	}
+	cyctime := d.Base.In(timezone)
+	bizdate := cyctime.AddDate(0, 0, -1)
+
+	var firstErr error
+	ret := templatePlaceholderRe.ReplaceAllStringFunc(tpl, func(placeholder string) string {
+		m := templatePlaceholderRe.FindStringSubmatch(placeholder)
+		expr := strings.TrimSpace(m[1] + m[2] + m[3])
+		base := cyctime
+		name := expr
+		if om := templateOffsetRe.FindStringSubmatch(expr); om != nil {
+			name = om[1]
+		}
+		if name == "bizdate" || name != "cyctime" && strings.HasPrefix(placeholder, "${") {
+			base = bizdate
+		}
+		value, err := d.eval(expr, base)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			return placeholder
+		}
+		return value
+	})
+	return ret, firstErr
+}
+
+// eval 计算单个占位符表达式
+func (d DateTemplate) eval(expr string, base time.Time) (string, error) {
+	if value, ok := d.Vars[expr]; ok {
+		return value, nil
+	}
+	if m := templateDsAddRe.FindStringSubmatch(expr); m != nil {
+		t, err := d.evalDate(m[1], base, FormatYYYYMMDD)
+		if err != nil {
+			return "", err
+		}
+		n, _ := strconv.Atoi(m[2])
+		return t.AddDate(0, 0, n).Format(FormatYYYYMMDD), nil
+	}
+	if m := templateDsFormatRe.FindStringSubmatch(expr); m != nil {
+		t, err := d.evalDate(m[1], base, strftimeToLayout(m[2]))
+		if err != nil {
+			return "", err
+		}
+		return t.Format(strftimeToLayout(m[3])), nil
+	}
+	if m := templateAddMonthsRe.FindStringSubmatch(expr); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		y, mo, day := addCivilMonths(base.Year(), base.Month(), base.Day(), n, LeapDayFeb28)
+		h, mi, s := base.Clock()
+		return d.format(m[1], time.Date(y, mo, day, h, mi, s, base.Nanosecond(), base.Location()))
+	}
+	if m := templateOffsetRe.FindStringSubmatch(expr); m != nil {
+		if value, err := d.format(m[1], shiftTemplateTime(base, m[2], m[3], m[4])); err == nil {
+			return value, nil
+		}
+	}
+	return d.format(expr, base)
+}
+
+// evalDate 将ds_add、ds_format的首个参数解析为时间，参数可以是变量名或带引号的日期字符串
+func (d DateTemplate) evalDate(arg string, base time.Time, layout string) (time.Time, error) {
+	if unquoted := strings.Trim(arg, `'"`); unquoted != arg {
+		t, err := time.ParseInLocation(layout, unquoted, base.Location())
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%w: %q", ErrTemplateVariable, arg)
+		}
+		return t, nil
+	}
+	value, err := d.eval(arg, base)
+	if err != nil {
+		return time.Time{}, err
+	}
+	t, err := time.ParseInLocation(layout, value, base.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %q", ErrTemplateVariable, arg)
+	}
+	return t, nil
+}
+
+// format 按内置变量名或Java风格格式输出时间
+func (d DateTemplate) format(name string, t time.Time) (string, error) {
+	if layout, ok := templateBuiltinLayouts[name]; ok {
+		return t.Format(layout), nil
+	}
+	if offset, ok := templateBuiltinOffsets[name]; ok {
+		layout := FormatYYYYMMDD
+		if strings.HasSuffix(name, "_nodash") {
+			layout = FormatYYYYMMDDNoSymbol
+		}
+		return t.AddDate(0, 0, offset).Format(layout), nil
+	}
+	layout, err := JavaLayoutToGo(name)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", ErrTemplateVariable, name)
+	}
+	return t.Format(layout), nil
+}
+
+// shiftTemplateTime 按"+N"、"-N/24"、"-N/24/60"偏移时间，没有除数时按自然日偏移
+func shiftTemplateTime(t time.Time, sign, amount, divisors string) time.Time {
+	n, _ := strconv.Atoi(amount)
+	if sign == "-" {
+		n = -n
+	}
+	if strings.TrimSpace(divisors) == "" {
+		return t.AddDate(0, 0, n)
+	}
+	offset := time.Duration(n) * 24 * time.Hour
+	for _, part := range strings.Split(divisors, "/") {
+		if div, err := strconv.Atoi(strings.TrimSpace(part)); err == nil && div > 0 {
+			offset /= time.Duration(div)
+		}
+	}
+	return t.Add(offset)
+}
+
+// JavaLayoutToGo 将Java/DataWorks风格的日期格式转换为Go的layout，例如"yyyy-MM-dd HH:mm:ss"、"yyyymmddhh24miss"。
+// 没有大写MM且没有HH/H时按DataWorks风格处理，小写mm表示月份，分钟只能使用mi；单引号内的内容原样输出
+func JavaLayoutToGo(layout string) (string, error) {
+	monthLower := !strings.Contains(layout, "MM") && !strings.Contains(layout, "H")
+	tokens := []struct{ java, golang string }{
+		{"yyyy", "2006"}, {"yy", "06"}, {"hh24", "15"}, {"HH", "15"}, {"hh", "03"},
+		{"MM", "01"}, {"dd", "02"}, {"mi", "04"}, {"mm", "04"}, {"ss", "05"}, {"SSS", "000"},
+		{"M", "1"}, {"d", "2"}, {"H", "15"}, {"m", "4"}, {"s", "5"}, {"a", "PM"},
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(layout); {
+		c := layout[i]
+		if c == '\'' {
+			end := strings.IndexByte(layout[i+1:], '\'')
+			if end < 0 {
+				return "", fmt.Errorf("timeutil: unterminated quote in layout %q", layout)
+			}
+			b.WriteString(layout[i+1 : i+1+end])
+			i += end + 2
+			continue
+		}
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z') {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		matched := false
+		for _, tk := range tokens {
+			if strings.HasPrefix(layout[i:], tk.java) {
+				golang := tk.golang
+				if tk.java == "mm" && monthLower {
+					golang = "01"
+				}
+				b.WriteString(golang)
+				i += len(tk.java)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return "", fmt.Errorf("timeutil: unknown layout token %q in %q", layout[i:i+1], layout)
+		}
+	}
+	return b.String(), nil
+}
+
+// strftimeToLayout 将Python strftime格式转换为Go的layout
+func strftimeToLayout(format string) string {
+	replacer := strings.NewReplacer(
+		"%Y", "2006", "%y", "06", "%m", "01", "%d", "02",
+		"%H", "15", "%I", "03", "%M", "04", "%S", "05", "%p", "PM",
+		"%b", "Jan", "%B", "January", "%a", "Mon", "%A", "Monday", "%%", "%",
+	)
+	return replacer.Replace(format)
+}
@@ -0,0 +1,90 @@
+package timeutil
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestExpandDateTemplate(t *testing.T) {
+	loc := getTestTimezone()
+	base := fixedTime(loc) // 2024-07-28 10:15:30
+
+	tests := []struct {
+		tpl      string
+		expected string
+	}{
+		{"select * from t where dt='${yyyyMMdd}'", "select * from t where dt='20240727'"},
+		{"/data/dt=${yyyy-MM-dd}/hr=${HH}", "/data/dt=2024-07-27/hr=10"},
+		{"${bizdate}", "20240727"},
+		{"${bizdate-1}", "20240726"},
+		{"${yyyyMMdd+7}", "20240803"},
+		{"${yyyy-MM-dd-1}", "2024-07-26"},
+		{"${bizdate}/$[yyyymmdd]", "20240727/20240728"},
+		{"$[bizdate]", "20240727"},
+		{"${cyctime}", "20240728101530"},
+		{"$[yyyymmddhh24miss]", "20240728101530"},
+		{"${yyyymm}", "202407"},
+		{"$[yyyymm]", "202407"},
+		{"${yyyy-mm}", "2024-07"},
+		{"$[hh24-1/24]", "09"},
+		{"$[yyyymmddhh24mi-30/24/60]", "202407280945"},
+		{"$[add_months(yyyymmdd,-1)]", "20240628"},
+		{"{{ds}}", "2024-07-28"},
+		{"{{ ds_nodash }}", "20240728"},
+		{"{{ ts }}", "2024-07-28T10:15:30+08:00"},
+		{"{{ts_nodash}}", "20240728T101530"},
+		{"{{ yesterday_ds_nodash }}", "20240727"},
+		{"{{ macros.ds_add(ds, -7) }}", "2024-07-21"},
+		{"{{ macros.ds_add('2024-03-01', -1) }}", "2024-02-29"},
+		{`{{ macros.ds_format(ds, "%Y-%m-%d", "%Y%m%d") }}`, "20240728"},
+		{"${yyyy}年${M}月${d}日", "2024年7月27日"},
+		{"no placeholder", "no placeholder"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tpl, func(t *testing.T) {
+			got, err := ExpandDateTemplate(tt.tpl, base, loc)
+			if err != nil || got != tt.expected {
+				t.Errorf("ExpandDateTemplate(%q) = %q, %v; want %q", tt.tpl, got, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDateTemplateVars(t *testing.T) {
+	tpl := DateTemplate{
+		Base:     fixedTime(getTestTimezone()),
+		Timezone: getTestTimezone(),
+		Vars:     map[string]string{"env": "prod", "ds": "override"},
+	}
+	got, err := tpl.Expand("/${env}/{{ds}}/${bizdate}")
+	if expected := "/prod/override/20240727"; err != nil || got != expected {
+		t.Errorf("Expand() = %q, %v; want %q", got, err, expected)
+	}
+
+	got, err = tpl.Expand("${unknown}/${bizdate}")
+	if !errors.Is(err, ErrTemplateVariable) || got != "${unknown}/20240727" {
+		t.Errorf("Expand() = %q, %v; want unexpanded placeholder and ErrTemplateVariable", got, err)
+	}
+}
+
+func TestJavaLayoutToGo(t *testing.T) {
+	tests := []struct {
+		layout   string
+		expected string
+	}{
+		{"yyyy-MM-dd HH:mm:ss", FormatYYYYMMDDHHMMSS},
+		{"yyyyMMdd", FormatYYYYMMDDNoSymbol},
+		{"yyyymmddhh24miss", FormatYYYYMMDDHHMMSSNoSymbol},
+		{"HH:mm", "15:04"},
+		{"yyyy-mm", "2006-01"},
+		{"yyyy-MM-dd'T'HH:mm:ss.SSS", "2006-01-02T15:04:05.000"},
+	}
+	for _, test := range tests {
+		if result, err := JavaLayoutToGo(test.layout); err != nil || result != test.expected {
+			t.Errorf("JavaLayoutToGo(%q) = %q, %v; want %q", test.layout, result, err, test.expected)
+		}
+	}
+	if _, err := JavaLayoutToGo("bizday"); err == nil {
+		t.Errorf("JavaLayoutToGo(%q) error = nil; want error", "bizday")
+	}
+}
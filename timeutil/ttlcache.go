@@ -0,0 +1,221 @@
+package timeutil
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var errLoadPanicked = errors.New("timeutil: TTLCache load panicked")
+
+// TTLCacheConfig TTL缓存配置
+type TTLCacheConfig struct {
+	DefaultTTL time.Duration // Set未指定TTL时的过期时长，<=0时永不过期
+	MaxSize    int           // 最多缓存的条目数，超过时淘汰最久未使用的条目，<=0时不限
+	Clock      Clock         // 时间源，nil时使用SystemClock
+}
+
+// TTLCache 按过期时间淘汰的缓存，过期条目在访问时或Sweep时清理，并发安全
+type TTLCache[K comparable, V any] struct {
+	mu         sync.Mutex
+	clock      Clock
+	defaultTTL time.Duration
+	maxSize    int
+	items      map[K]*list.Element
+	lru        *list.List // 队首为最近使用的条目
+	loads      map[K]*ttlLoad[V]
+}
+
+type ttlEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	expires time.Time // 零值表示永不过期
+}
+
+// ttlLoad 进行中的GetOrLoad，同一key的并发调用共享结果
+type ttlLoad[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+// NewTTLCache 创建TTL缓存
+func NewTTLCache[K comparable, V any](config TTLCacheConfig) *TTLCache[K, V] {
+	return &TTLCache[K, V]{
+		clock:      orSystemClock(config.Clock),
+		defaultTTL: config.DefaultTTL,
+		maxSize:    config.MaxSize,
+		items:      make(map[K]*list.Element),
+		lru:        list.New(),
+		loads:      make(map[K]*ttlLoad[V]),
+	}
+}
+
+// Get 获取未过期的缓存值
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.get(key, c.clock.Now())
+}
+
+// Set 缓存value，ttl为0时使用DefaultTTL，<0时永不过期
+func (c *TTLCache[K, V]) Set(key K, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, value, c.expiresAt(ttl))
+}
+
+// SetUntil 缓存value直到指定时间，例如SetUntil(k, v, NextTimeOfDay(now, 18, 0, tz))在当天下班时过期
+func (c *TTLCache[K, V]) SetUntil(key K, value V, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.set(key, value, expires)
+}
+
+// GetOrLoad 获取缓存值，不存在或已过期时调用load加载并按DefaultTTL缓存。
+// 同一key的并发调用只会执行一次load，load返回错误时不缓存
+func (c *TTLCache[K, V]) GetOrLoad(key K, load func(key K) (V, error)) (V, error) {
+	c.mu.Lock()
+	if value, ok := c.get(key, c.clock.Now()); ok {
+		c.mu.Unlock()
+		return value, nil
+	}
+	if l, ok := c.loads[key]; ok {
+		c.mu.Unlock()
+		<-l.done
+		return l.value, l.err
+	}
+	l := &ttlLoad[V]{done: make(chan struct{})}
+	c.loads[key] = l
+	c.mu.Unlock()
+
+	loaded := false
+	defer func() {
+		if !loaded {
+			// load发生panic，等待中的调用返回错误
+			l.err = errLoadPanicked
+		}
+		c.mu.Lock()
+		delete(c.loads, key)
+		if l.err == nil {
+			c.set(key, l.value, c.expiresAt(0))
+		}
+		c.mu.Unlock()
+		close(l.done)
+	}()
+	l.value, l.err = load(key)
+	loaded = true
+	return l.value, l.err
+}
+
+// Delete 删除缓存值
+func (c *TTLCache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+}
+
+// Len 缓存的条目数，包括已过期但尚未清理的条目
+func (c *TTLCache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.items)
+}
+
+// Sweep 清理所有已过期的条目，返回清理的数量
+func (c *TTLCache[K, V]) Sweep() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	n := 0
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if c.expired(elem.Value.(*ttlEntry[K, V]), now) {
+			c.remove(elem)
+			n++
+		}
+		elem = next
+	}
+	return n
+}
+
+// StartSweeper 启动后台goroutine每隔interval调用一次Sweep，直到ctx结束
+func (c *TTLCache[K, V]) StartSweeper(ctx context.Context, interval time.Duration) {
+	ticker := c.clock.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C():
+				c.Sweep()
+			}
+		}
+	}()
+}
+
+// expiresAt ttl对应的过期时间，调用方需持有锁
+func (c *TTLCache[K, V]) expiresAt(ttl time.Duration) time.Time {
+	if ttl == 0 {
+		ttl = c.defaultTTL
+	}
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return c.clock.Now().Add(ttl)
+}
+
+func (c *TTLCache[K, V]) expired(entry *ttlEntry[K, V], now time.Time) bool {
+	return !entry.expires.IsZero() && !now.Before(entry.expires)
+}
+
+// get 获取未过期的缓存值并标记为最近使用，调用方需持有锁
+func (c *TTLCache[K, V]) get(key K, now time.Time) (V, bool) {
+	var zero V
+	elem, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+	entry := elem.Value.(*ttlEntry[K, V])
+	if c.expired(entry, now) {
+		c.remove(elem)
+		return zero, false
+	}
+	c.lru.MoveToFront(elem)
+	return entry.value, true
+}
+
+// set 写入缓存值，超过MaxSize时淘汰最久未使用的条目，调用方需持有锁
+func (c *TTLCache[K, V]) set(key K, value V, expires time.Time) {
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*ttlEntry[K, V])
+		entry.value, entry.expires = value, expires
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.items[key] = c.lru.PushFront(&ttlEntry[K, V]{key: key, value: value, expires: expires})
+	for c.maxSize > 0 && c.lru.Len() > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *TTLCache[K, V]) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.items, elem.Value.(*ttlEntry[K, V]).key)
+}
+
+// NextTimeOfDay 获取t之后(不含t)timezone下第一个hour:minute的时间，例如下一个18:00
+func NextTimeOfDay(t time.Time, hour, minute int, timezone *time.Location) time.Time {
+	local := t.In(timezone)
+	y, m, d := local.Date()
+	next := time.Date(y, m, d, hour, minute, 0, 0, timezone)
+	if !next.After(t) {
+		next = time.Date(y, m, d+1, hour, minute, 0, 0, timezone)
+	}
+	return next
+}
@@ -0,0 +1,140 @@
+package timeutil
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTTLCache(t *testing.T) {
+	clock := NewFakeClock(fixedTime(getTestTimezone()))
+	c := NewTTLCache[string, int](TTLCacheConfig{DefaultTTL: time.Minute, Clock: clock})
+
+	c.Set("a", 1, 0)
+	c.Set("b", 2, 10*time.Second)
+	c.Set("c", 3, -1)
+	clock.Advance(10 * time.Second)
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("Get(b) after ttl ok = true; want false")
+	}
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = %v, %v; want 1, true", v, ok)
+	}
+	clock.Advance(time.Hour)
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Get(a) after default ttl ok = true; want false")
+	}
+	if v, ok := c.Get("c"); !ok || v != 3 {
+		t.Errorf("Get(c) without ttl = %v, %v; want 3, true", v, ok)
+	}
+
+	c.Delete("c")
+	if got := c.Len(); got != 0 {
+		t.Errorf("Len() = %d; want 0", got)
+	}
+}
+
+func TestTTLCacheSetUntil(t *testing.T) {
+	loc := getTestTimezone()
+	clock := NewFakeClock(fixedTime(loc))
+	c := NewTTLCache[string, string](TTLCacheConfig{Clock: clock})
+
+	endOfBusiness := NextTimeOfDay(clock.Now(), 18, 0, loc)
+	if expected := time.Date(2024, 7, 28, 18, 0, 0, 0, loc); !endOfBusiness.Equal(expected) {
+		t.Errorf("NextTimeOfDay(18:00) = %v; want %v", endOfBusiness, expected)
+	}
+	if got, expected := NextTimeOfDay(clock.Now(), 9, 0, loc), time.Date(2024, 7, 29, 9, 0, 0, 0, loc); !got.Equal(expected) {
+		t.Errorf("NextTimeOfDay(09:00) = %v; want %v", got, expected)
+	}
+
+	c.SetUntil("config", "v1", endOfBusiness)
+	clock.AdvanceTo(endOfBusiness.Add(-time.Second))
+	if _, ok := c.Get("config"); !ok {
+		t.Errorf("Get(config) before end of business ok = false; want true")
+	}
+	clock.Advance(time.Second)
+	if _, ok := c.Get("config"); ok {
+		t.Errorf("Get(config) at end of business ok = true; want false")
+	}
+}
+
+func TestTTLCacheLRU(t *testing.T) {
+	c := NewTTLCache[int, int](TTLCacheConfig{MaxSize: 2})
+	c.Set(1, 1, 0)
+	c.Set(2, 2, 0)
+	c.Get(1)
+	c.Set(3, 3, 0)
+	if _, ok := c.Get(2); ok {
+		t.Errorf("least recently used key 2 was not evicted")
+	}
+	if _, ok := c.Get(1); !ok {
+		t.Errorf("recently used key 1 was evicted")
+	}
+	if got := c.Len(); got != 2 {
+		t.Errorf("Len() = %d; want 2", got)
+	}
+}
+
+func TestTTLCacheGetOrLoad(t *testing.T) {
+	clock := NewFakeClock(fixedTime(getTestTimezone()))
+	c := NewTTLCache[string, int](TTLCacheConfig{DefaultTTL: time.Minute, Clock: clock})
+
+	var calls atomic.Int32
+	release := make(chan struct{})
+	load := func(key string) (int, error) {
+		calls.Add(1)
+		<-release
+		return len(key), nil
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := c.GetOrLoad("holiday", load); v != 7 || err != nil {
+				t.Errorf("GetOrLoad() = %v, %v; want 7, nil", v, err)
+			}
+		}()
+	}
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+	if got := calls.Load(); got != 1 {
+		t.Errorf("load called %d times; want 1", got)
+	}
+
+	errLoad := errors.New("load failed")
+	if _, err := c.GetOrLoad("bad", func(string) (int, error) { return 0, errLoad }); err != errLoad {
+		t.Errorf("GetOrLoad(bad) error = %v; want %v", err, errLoad)
+	}
+	if _, ok := c.Get("bad"); ok {
+		t.Errorf("failed load was cached")
+	}
+}
+
+func TestTTLCacheSweeper(t *testing.T) {
+	clock := NewFakeClock(fixedTime(getTestTimezone()))
+	c := NewTTLCache[string, int](TTLCacheConfig{DefaultTTL: time.Minute, Clock: clock})
+	c.Set("a", 1, 0)
+	c.Set("b", 2, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c.StartSweeper(ctx, 5*time.Minute)
+	clock.Advance(5 * time.Minute)
+	deadline := time.Now().Add(time.Second)
+	for c.Len() != 1 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if got := c.Len(); got != 1 {
+		t.Errorf("Len() after sweep = %d; want 1", got)
+	}
+	if got := c.Sweep(); got != 0 {
+		t.Errorf("Sweep() = %d; want 0", got)
+	}
+}
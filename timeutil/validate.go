@@ -0,0 +1,260 @@
+package timeutil
+
+import (
+	"strings"
+	"time"
+)
+
+// Locale 语言区域，用于本地化的提示信息及FormatLocale、ParseLocale
+type Locale string
+
+const (
+	LocaleZhCN Locale = "zh-CN" // 简体中文
+	LocaleZhTW Locale = "zh-TW" // 繁体中文(台湾)
+	LocaleJaJP Locale = "ja-JP" // 日语
+	LocaleEnUS Locale = "en-US" // 美国英语
+)
+
+// DateErrorCode 日期校验失败的原因
+type DateErrorCode string
+
+const (
+	DateErrRequired    DateErrorCode = "required"     // 值为空
+	DateErrFormat      DateErrorCode = "format"       // 不符合任何格式
+	DateErrMin         DateErrorCode = "min"          // 早于最小值
+	DateErrMax         DateErrorCode = "max"          // 晚于最大值
+	DateErrWeekday     DateErrorCode = "weekday"      // 不是允许的星期
+	DateErrFuture      DateErrorCode = "future"       // 晚于今天
+	DateErrPast        DateErrorCode = "past"         // 早于今天
+	DateErrBusinessDay DateErrorCode = "business_day" // 不是工作日
+	DateErrLteField    DateErrorCode = "lte_field"    // 晚于另一个字段
+	DateErrGteField    DateErrorCode = "gte_field"    // 早于另一个字段
+	DateErrMaxSpan     DateErrorCode = "max_span"     // 与另一个字段的间隔超过上限
+)
+
+// DateValidationError 日期校验失败的原因，可按语言区域生成提示信息，适合作为接口的校验错误返回
+type DateValidationError struct {
+	Code   DateErrorCode     // 失败原因
+	Field  string            // 字段名
+	Value  string            // 校验的原始值
+	Params map[string]string // 提示信息中的参数，例如layouts、min、max、weekdays、other、span
+}
+
+// Error 英文提示信息
+func (e *DateValidationError) Error() string {
+	return "timeutil: " + e.Message(LocaleEnUS)
+}
+
+// Message 按语言区域生成提示信息，不支持的语言区域使用英文
+func (e *DateValidationError) Message(locale Locale) string {
+	messages, ok := dateErrorMessages[locale]
+	if !ok {
+		locale, messages = LocaleEnUS, dateErrorMessages[LocaleEnUS]
+	}
+	field := e.Field
+	if field == "" {
+		field = dateFieldNames[locale]
+	}
+	pairs := []string{"{field}", field, "{value}", e.Value}
+	for k, v := range e.Params {
+		if k == "weekdays" {
+			v = localizeWeekdays(v, locale)
+		}
+		pairs = append(pairs, "{"+k+"}", v)
+	}
+	return strings.NewReplacer(pairs...).Replace(messages[e.Code])
+}
+
+var dateFieldNames = map[Locale]string{
+	LocaleZhCN: "日期",
+	LocaleZhTW: "日期",
+	LocaleJaJP: "日付",
+	LocaleEnUS: "date",
+}
+
+var dateErrorMessages = map[Locale]map[DateErrorCode]string{
+	LocaleZhCN: {
+		DateErrRequired:    "{field}不能为空",
+		DateErrFormat:      "{field}格式错误，应为{layouts}",
+		DateErrMin:         "{field}不能早于{min}",
+		DateErrMax:         "{field}不能晚于{max}",
+		DateErrWeekday:     "{field}必须是{weekdays}",
+		DateErrFuture:      "{field}不能晚于今天",
+		DateErrPast:        "{field}不能早于今天",
+		DateErrBusinessDay: "{field}必须是工作日",
+		DateErrLteField:    "{field}不能晚于{other}",
+		DateErrGteField:    "{field}不能早于{other}",
+		DateErrMaxSpan:     "{field}与{other}的间隔不能超过{span}",
+	},
+	LocaleZhTW: {
+		DateErrRequired:    "{field}不能為空",
+		DateErrFormat:      "{field}格式錯誤，應為{layouts}",
+		DateErrMin:         "{field}不能早於{min}",
+		DateErrMax:         "{field}不能晚於{max}",
+		DateErrWeekday:     "{field}必須是{weekdays}",
+		DateErrFuture:      "{field}不能晚於今天",
+		DateErrPast:        "{field}不能早於今天",
+		DateErrBusinessDay: "{field}必須是工作日",
+		DateErrLteField:    "{field}不能晚於{other}",
+		DateErrGteField:    "{field}不能早於{other}",
+		DateErrMaxSpan:     "{field}與{other}的間隔不能超過{span}",
+	},
+	LocaleJaJP: {
+		DateErrRequired:    "{field}を入力してください",
+		DateErrFormat:      "{field}は{layouts}の形式で入力してください",
+		DateErrMin:         "{field}は{min}以降の日付を指定してください",
+		DateErrMax:         "{field}は{max}以前の日付を指定してください",
+		DateErrWeekday:     "{field}は{weekdays}を指定してください",
+		DateErrFuture:      "{field}に未来の日付は指定できません",
+		DateErrPast:        "{field}に過去の日付は指定できません",
+		DateErrBusinessDay: "{field}は営業日を指定してください",
+		DateErrLteField:    "{field}は{other}以前の日付を指定してください",
+		DateErrGteField:    "{field}は{other}以降の日付を指定してください",
+		DateErrMaxSpan:     "{field}と{other}の間隔は{span}以内にしてください",
+	},
+	LocaleEnUS: {
+		DateErrRequired:    "{field} is required",
+		DateErrFormat:      "{field} must match format {layouts}",
+		DateErrMin:         "{field} must not be before {min}",
+		DateErrMax:         "{field} must not be after {max}",
+		DateErrWeekday:     "{field} must be {weekdays}",
+		DateErrFuture:      "{field} must not be in the future",
+		DateErrPast:        "{field} must not be in the past",
+		DateErrBusinessDay: "{field} must be a business day",
+		DateErrLteField:    "{field} must not be after {other}",
+		DateErrGteField:    "{field} must not be before {other}",
+		DateErrMaxSpan:     "{field} must be within {span} of {other}",
+	},
+}
+
+// localizeWeekdays 将逗号分隔的英文星期名转换为语言区域的列表
+func localizeWeekdays(weekdays string, locale Locale) string {
+	names := strings.Split(weekdays, ",")
+	if _, ok := dateErrorMessages[locale]; !ok || locale == LocaleEnUS {
+		return strings.Join(names, ", ")
+	}
+	for i, name := range names {
+		for wd := time.Sunday; wd <= time.Saturday; wd++ {
+			if wd.String() == name {
+				// 日语的简称只有一个字，使用全称
+				names[i] = WeekdayName(wd, locale, locale != LocaleJaJP)
+			}
+		}
+	}
+	return strings.Join(names, "、")
+}
+
+// DateValidationErrors 多个日期校验失败的原因
+type DateValidationErrors []*DateValidationError
+
+func (errs DateValidationErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Messages 按语言区域生成所有提示信息
+func (errs DateValidationErrors) Messages(locale Locale) []string {
+	messages := make([]string, len(errs))
+	for i, e := range errs {
+		messages[i] = e.Message(locale)
+	}
+	return messages
+}
+
+// DateRule 日期校验规则
+type DateRule struct {
+	Field         string                 // 字段名，用于提示信息
+	Layouts       []string               // 允许的格式，依次尝试，为空时为FormatYYYYMMDDNoSymbol
+	Timezone      *time.Location         // 解析和比较使用的时区，nil时为UTC
+	Min           time.Time              // 最小值(含)，零值时不限
+	Max           time.Time              // 最大值(含)，零值时不限
+	Weekdays      []time.Weekday         // 允许的星期，为空时不限
+	NotFuture     bool                   // 不允许晚于今天，按Timezone的自然日比较
+	NotPast       bool                   // 不允许早于今天，按Timezone的自然日比较
+	BusinessDay   bool                   // 必须是工作日
+	IsBusinessDay func(t time.Time) bool // 判断工作日，nil时为非周末
+	Clock         Clock                  // 判断今天使用的时间源，nil时使用SystemClock
+}
+
+// Validate 按规则校验日期字符串，返回解析后的时间。
+// 不符合格式时只返回格式错误，其余规则全部检查后以DateValidationErrors返回所有失败原因
+func (r DateRule) Validate(value string) (time.Time, error) {
+	timezone := r.Timezone
+	if timezone == nil {
+		timezone = TimezoneUtc
+	}
+	layouts := r.Layouts
+	if len(layouts) == 0 {
+		layouts = []string{FormatYYYYMMDDNoSymbol}
+	}
+	newError := func(code DateErrorCode, params map[string]string) *DateValidationError {
+		return &DateValidationError{Code: code, Field: r.Field, Value: value, Params: params}
+	}
+
+	if strings.TrimSpace(value) == "" {
+		return time.Time{}, DateValidationErrors{newError(DateErrRequired, nil)}
+	}
+	t, ok := parseInLayouts(value, layouts, timezone)
+	if !ok {
+		return time.Time{}, DateValidationErrors{newError(DateErrFormat, map[string]string{"layouts": strings.Join(layouts, ", ")})}
+	}
+
+	var errs DateValidationErrors
+	layout := layouts[0]
+	if !r.Min.IsZero() && t.Before(r.Min) {
+		errs = append(errs, newError(DateErrMin, map[string]string{"min": r.Min.In(timezone).Format(layout)}))
+	}
+	if !r.Max.IsZero() && t.After(r.Max) {
+		errs = append(errs, newError(DateErrMax, map[string]string{"max": r.Max.In(timezone).Format(layout)}))
+	}
+	if len(r.Weekdays) > 0 && !containsWeekday(r.Weekdays, t.Weekday()) {
+		names := make([]string, len(r.Weekdays))
+		for i, wd := range r.Weekdays {
+			names[i] = wd.String()
+		}
+		errs = append(errs, newError(DateErrWeekday, map[string]string{"weekdays": strings.Join(names, ",")}))
+	}
+	if r.NotFuture || r.NotPast {
+		today := StartOfDay(orSystemClock(r.Clock).Now(), timezone)
+		if r.NotFuture && !t.Before(today.AddDate(0, 0, 1)) {
+			errs = append(errs, newError(DateErrFuture, nil))
+		}
+		if r.NotPast && t.Before(today) {
+			errs = append(errs, newError(DateErrPast, nil))
+		}
+	}
+	if r.BusinessDay {
+		isBusinessDay := r.IsBusinessDay
+		if isBusinessDay == nil {
+			isBusinessDay = func(t time.Time) bool { return !IsWeekend(t, timezone) }
+		}
+		if !isBusinessDay(t) {
+			errs = append(errs, newError(DateErrBusinessDay, nil))
+		}
+	}
+	if len(errs) > 0 {
+		return t, errs
+	}
+	return t, nil
+}
+
+func parseInLayouts(value string, layouts []string, timezone *time.Location) (time.Time, bool) {
+	for _, layout := range layouts {
+		if t, err := time.ParseInLocation(layout, value, timezone); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+func containsWeekday(weekdays []time.Weekday, wd time.Weekday) bool {
+	for _, w := range weekdays {
+		if w == wd {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package timeutil
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDateRuleValidate(t *testing.T) {
+	loc := getTestTimezone()
+	clock := NewFakeClock(fixedTime(loc)) // 2024-07-28 周日
+	tests := []struct {
+		name     string
+		rule     DateRule
+		value    string
+		expected []DateErrorCode
+	}{
+		{"valid", DateRule{}, "20240726", nil},
+		{"empty", DateRule{}, " ", []DateErrorCode{DateErrRequired}},
+		{"format", DateRule{}, "2024-07-26", []DateErrorCode{DateErrFormat}},
+		{"second layout", DateRule{Layouts: []string{FormatYYYYMMDDNoSymbol, FormatYYYYMMDD}}, "2024-07-26", nil},
+		{"invalid day", DateRule{}, "20240230", []DateErrorCode{DateErrFormat}},
+		{"min max", DateRule{Min: time.Date(2024, 7, 1, 0, 0, 0, 0, loc), Max: time.Date(2024, 7, 31, 0, 0, 0, 0, loc)}, "20240731", nil},
+		{"before min", DateRule{Min: time.Date(2024, 7, 1, 0, 0, 0, 0, loc)}, "20240630", []DateErrorCode{DateErrMin}},
+		{"after max", DateRule{Max: time.Date(2024, 7, 31, 0, 0, 0, 0, loc)}, "20240801", []DateErrorCode{DateErrMax}},
+		{"weekday", DateRule{Weekdays: []time.Weekday{time.Monday, time.Friday}}, "20240726", nil},
+		{"bad weekday", DateRule{Weekdays: []time.Weekday{time.Monday}}, "20240726", []DateErrorCode{DateErrWeekday}},
+		{"today not future", DateRule{NotFuture: true, Clock: clock}, "20240728", nil},
+		{"future", DateRule{NotFuture: true, Clock: clock}, "20240729", []DateErrorCode{DateErrFuture}},
+		{"today not past", DateRule{NotPast: true, Clock: clock}, "20240728", nil},
+		{"past", DateRule{NotPast: true, Clock: clock}, "20240727", []DateErrorCode{DateErrPast}},
+		{"business day", DateRule{BusinessDay: true}, "20240726", nil},
+		{"weekend", DateRule{BusinessDay: true}, "20240727", []DateErrorCode{DateErrBusinessDay}},
+		{"custom business day", DateRule{BusinessDay: true, IsBusinessDay: func(t time.Time) bool { return t.Day() != 26 }}, "20240726", []DateErrorCode{DateErrBusinessDay}},
+		{"multiple", DateRule{NotFuture: true, BusinessDay: true, Clock: clock}, "20240803", []DateErrorCode{DateErrFuture, DateErrBusinessDay}},
+	}
+	for _, test := range tests {
+		test.rule.Timezone = loc
+		_, err := test.rule.Validate(test.value)
+		var codes []DateErrorCode
+		var errs DateValidationErrors
+		if errors.As(err, &errs) {
+			for _, e := range errs {
+				codes = append(codes, e.Code)
+			}
+		} else if err != nil {
+			t.Errorf("%s: Validate(%q) error type %T", test.name, test.value, err)
+		}
+		if !reflect.DeepEqual(codes, test.expected) {
+			t.Errorf("%s: Validate(%q) codes = %v; want %v", test.name, test.value, codes, test.expected)
+		}
+	}
+
+	got, err := DateRule{Timezone: loc}.Validate("20240726")
+	if expected := time.Date(2024, 7, 26, 0, 0, 0, 0, loc); err != nil || !got.Equal(expected) {
+		t.Errorf("Validate(20240726) = %v, %v; want %v, nil", got, err, expected)
+	}
+}
+
+func TestDateValidationErrorMessage(t *testing.T) {
+	loc := getTestTimezone()
+	rule := DateRule{
+		Field:    "StartDay",
+		Timezone: loc,
+		Min:      time.Date(2024, 7, 1, 0, 0, 0, 0, loc),
+		Weekdays: []time.Weekday{time.Monday, time.Tuesday},
+	}
+	_, err := rule.Validate("20240630")
+	var errs DateValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Validate() error = %v; want DateValidationErrors", err)
+	}
+
+	expectedZh := []string{"StartDay不能早于20240701", "StartDay必须是周一、周二"}
+	if got := errs.Messages(LocaleZhCN); !reflect.DeepEqual(got, expectedZh) {
+		t.Errorf("Messages(zh-CN) = %q; want %q", got, expectedZh)
+	}
+	expectedTw := []string{"StartDay不能早於20240701", "StartDay必須是週一、週二"}
+	if got := errs.Messages(LocaleZhTW); !reflect.DeepEqual(got, expectedTw) {
+		t.Errorf("Messages(zh-TW) = %q; want %q", got, expectedTw)
+	}
+	expectedJa := []string{"StartDayは20240701以降の日付を指定してください", "StartDayは月曜日、火曜日を指定してください"}
+	if got := errs.Messages(LocaleJaJP); !reflect.DeepEqual(got, expectedJa) {
+		t.Errorf("Messages(ja-JP) = %q; want %q", got, expectedJa)
+	}
+	expectedEn := []string{"StartDay must not be before 20240701", "StartDay must be Monday, Tuesday"}
+	if got := errs.Messages(LocaleEnUS); !reflect.DeepEqual(got, expectedEn) {
+		t.Errorf("Messages(en-US) = %q; want %q", got, expectedEn)
+	}
+
+	_, err = DateRule{}.Validate("2024/07/01")
+	if !errors.As(err, &errs) || errs[0].Message(LocaleZhCN) != "日期格式错误，应为20060102" {
+		t.Errorf("format error message = %v", err)
+	}
+	if got := errs[0].Message("fr-FR"); got != "date must match format 20060102" {
+		t.Errorf("Message(fr-FR) = %q; want English fallback", got)
+	}
+	if got := err.Error(); got != "timeutil: date must match format 20060102" {
+		t.Errorf("Error() = %q", got)
+	}
+}
@@ -0,0 +1,289 @@
+package timeutil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DateTagName 结构体中声明日期校验规则的tag名，与go-playground/validator一致
+const DateTagName = "validate"
+
+// FieldLevel 校验函数获取字段信息的接口，是go-playground/validator中validator.FieldLevel的子集，
+// 因此可以这样注册：v.RegisterValidation(tag, func(fl validator.FieldLevel) bool { return fn(fl) })
+type FieldLevel interface {
+	// Field 当前字段的值
+	Field() reflect.Value
+	// Parent 当前字段所在的结构体
+	Parent() reflect.Value
+	// StructFieldName 当前字段在结构体中的名称
+	StructFieldName() string
+	// Param tag中的参数，例如date=20060102中的20060102
+	Param() string
+}
+
+// dateTagLayouts 字段未声明date格式时依次尝试的格式
+var dateTagLayouts = []string{FormatYYYYMMDDNoSymbol, FormatYYYYMMDD, FormatYYYYMMDDHHMMSS, time.RFC3339}
+
+// DateTagValidators 日期相关的tag校验函数，key为tag名：
+//   - date=<layout>：字段为符合layout的日期，例如date=20060102
+//   - lte_field=<字段名>：不晚于另一个字段
+//   - gte_field=<字段名>：不早于另一个字段
+//   - max_span=<时长>：与lte_field或gte_field指定的字段间隔不超过该时长，支持d(天)、w(周)及time.ParseDuration的单位，例如31d
+//
+// 字段可以是string、time.Time或它们的指针，空值均视为通过，需要必填时配合required使用
+func DateTagValidators() map[string]func(fl FieldLevel) bool {
+	return map[string]func(fl FieldLevel) bool{
+		"date":      ValidateDateTag,
+		"lte_field": ValidateLteFieldTag,
+		"gte_field": ValidateGteFieldTag,
+		"max_span":  ValidateMaxSpanTag,
+	}
+}
+
+// ValidateDateTag 校验date=<layout>
+func ValidateDateTag(fl FieldLevel) bool {
+	_, empty, ok := fieldTime(fl.Field(), []string{fl.Param()})
+	return empty || ok
+}
+
+// ValidateLteFieldTag 校验lte_field=<字段名>
+func ValidateLteFieldTag(fl FieldLevel) bool {
+	t, other, ok := pairedTimes(fl, fl.Param())
+	return !ok || !t.After(other)
+}
+
+// ValidateGteFieldTag 校验gte_field=<字段名>
+func ValidateGteFieldTag(fl FieldLevel) bool {
+	t, other, ok := pairedTimes(fl, fl.Param())
+	return !ok || !t.Before(other)
+}
+
+// ValidateMaxSpanTag 校验max_span=<时长>，参数不合法时校验失败
+func ValidateMaxSpanTag(fl FieldLevel) bool {
+	span, err := parseSpan(fl.Param())
+	if err != nil {
+		return false
+	}
+	other := pairedFieldName(fl.Parent(), fl.StructFieldName())
+	if other == "" {
+		return true
+	}
+	t, o, ok := pairedTimes(fl, other)
+	if !ok {
+		return true
+	}
+	d := t.Sub(o)
+	if d < 0 {
+		d = -d
+	}
+	return d <= span
+}
+
+// ValidateDateStruct 不依赖第三方库，按结构体字段的validate tag校验日期规则，支持DateTagValidators中的tag及required，其余tag忽略。
+// 嵌套的结构体字段会递归校验，失败时返回DateValidationErrors，字段名为结构体中的名称
+func ValidateDateStruct(v any) error {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("timeutil: ValidateDateStruct expects a struct, got %T", v)
+	}
+	var errs DateValidationErrors
+	validateDateStruct(rv, "", &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func validateDateStruct(rv reflect.Value, prefix string, errs *DateValidationErrors) {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		sf := rt.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		field := rv.Field(i)
+		name := prefix + sf.Name
+		if fv := reflect.Indirect(field); fv.Kind() == reflect.Struct && fv.Type() != reflect.TypeOf(time.Time{}) {
+			validateDateStruct(fv, name+".", errs)
+			continue
+		}
+
+		for _, tag := range parseDateTag(sf.Tag.Get(DateTagName)) {
+			fl := structFieldLevel{parent: rv, field: field, name: sf.Name, param: tag.param}
+			if e := checkDateTag(fl, tag.name); e != nil {
+				e.Field = name
+				e.Value = fieldString(field)
+				*errs = append(*errs, e)
+			}
+		}
+	}
+}
+
+// checkDateTag 校验单个tag，通过或不支持的tag返回nil
+func checkDateTag(fl structFieldLevel, tag string) *DateValidationError {
+	switch tag {
+	case "required":
+		if _, empty, _ := fieldTime(fl.field, nil); empty {
+			return &DateValidationError{Code: DateErrRequired}
+		}
+	case "date":
+		if !ValidateDateTag(fl) {
+			return &DateValidationError{Code: DateErrFormat, Params: map[string]string{"layouts": fl.param}}
+		}
+	case "lte_field":
+		if !ValidateLteFieldTag(fl) {
+			return &DateValidationError{Code: DateErrLteField, Params: map[string]string{"other": fl.param}}
+		}
+	case "gte_field":
+		if !ValidateGteFieldTag(fl) {
+			return &DateValidationError{Code: DateErrGteField, Params: map[string]string{"other": fl.param}}
+		}
+	case "max_span":
+		if !ValidateMaxSpanTag(fl) {
+			other := pairedFieldName(fl.parent, fl.name)
+			return &DateValidationError{Code: DateErrMaxSpan, Params: map[string]string{"other": other, "span": fl.param}}
+		}
+	}
+	return nil
+}
+
+// structFieldLevel ValidateDateStruct使用的FieldLevel
+type structFieldLevel struct {
+	parent reflect.Value
+	field  reflect.Value
+	name   string
+	param  string
+}
+
+func (fl structFieldLevel) Field() reflect.Value    { return fl.field }
+func (fl structFieldLevel) Parent() reflect.Value   { return fl.parent }
+func (fl structFieldLevel) StructFieldName() string { return fl.name }
+func (fl structFieldLevel) Param() string           { return fl.param }
+
+type dateTag struct {
+	name, param string
+}
+
+// parseDateTag 解析validate tag，例如"required,date=20060102,lte_field=EndDay"
+func parseDateTag(tag string) []dateTag {
+	var tags []dateTag
+	for _, part := range strings.Split(tag, ",") {
+		name, param, _ := strings.Cut(strings.TrimSpace(part), "=")
+		if name != "" {
+			tags = append(tags, dateTag{name: name, param: param})
+		}
+	}
+	return tags
+}
+
+// tagParam 结构体字段validate tag中指定tag的参数
+func tagParam(parent reflect.Value, fieldName, tagName string) string {
+	if parent.Kind() != reflect.Struct {
+		return ""
+	}
+	sf, ok := parent.Type().FieldByName(fieldName)
+	if !ok {
+		return ""
+	}
+	for _, tag := range parseDateTag(sf.Tag.Get(DateTagName)) {
+		if tag.name == tagName {
+			return tag.param
+		}
+	}
+	return ""
+}
+
+// fieldLayouts 字段的日期格式，优先使用tag中声明的date格式
+func fieldLayouts(parent reflect.Value, fieldName string) []string {
+	if layout := tagParam(parent, fieldName, "date"); layout != "" {
+		return []string{layout}
+	}
+	return dateTagLayouts
+}
+
+// pairedFieldName max_span比较的字段，即同一字段lte_field或gte_field指定的字段
+func pairedFieldName(parent reflect.Value, fieldName string) string {
+	if other := tagParam(parent, fieldName, "lte_field"); other != "" {
+		return other
+	}
+	return tagParam(parent, fieldName, "gte_field")
+}
+
+// pairedTimes 解析当前字段和另一个字段的时间，任一为空或无法解析时ok为false
+func pairedTimes(fl FieldLevel, otherName string) (t, other time.Time, ok bool) {
+	parent := reflect.Indirect(fl.Parent())
+	if parent.Kind() != reflect.Struct {
+		return t, other, false
+	}
+	otherField := parent.FieldByName(otherName)
+	if !otherField.IsValid() {
+		return t, other, false
+	}
+	t, _, ok1 := fieldTime(fl.Field(), fieldLayouts(parent, fl.StructFieldName()))
+	other, _, ok2 := fieldTime(otherField, fieldLayouts(parent, otherName))
+	return t, other, ok1 && ok2
+}
+
+// fieldTime 将string、time.Time或它们的指针解析为时间，layouts为nil时只判断是否为空
+func fieldTime(v reflect.Value, layouts []string) (t time.Time, empty bool, ok bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return t, true, false
+		}
+		v = v.Elem()
+	}
+	switch {
+	case v.Kind() == reflect.String:
+		s := strings.TrimSpace(v.String())
+		if s == "" {
+			return t, true, false
+		}
+		t, ok = parseInLayouts(s, layouts, time.UTC)
+		return t, false, ok
+	case v.Type() == reflect.TypeOf(time.Time{}):
+		t = v.Interface().(time.Time)
+		return t, t.IsZero(), !t.IsZero()
+	}
+	return t, false, false
+}
+
+func fieldString(v reflect.Value) string {
+	v = reflect.Indirect(v)
+	if !v.IsValid() {
+		return ""
+	}
+	if v.Kind() == reflect.String {
+		return v.String()
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+var errInvalidSpan = errors.New("timeutil: invalid span")
+
+// parseSpan 解析时长，支持d(天)、w(周)及time.ParseDuration的单位
+func parseSpan(s string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil || v < 0 {
+				return 0, fmt.Errorf("%w: %q", errInvalidSpan, s)
+			}
+			return time.Duration(v) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("%w: %q", errInvalidSpan, s)
+	}
+	return d, nil
+}
@@ -0,0 +1,105 @@
+package timeutil
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type dateRangeRequest struct {
+	StartDay string  `validate:"required,date=20060102,lte_field=EndDay,max_span=31d"`
+	EndDay   string  `validate:"required,date=20060102"`
+	Since    *string `validate:"date=2006-01-02,gte_field=StartDay"`
+	Filter   struct {
+		From time.Time `validate:"lte_field=To,max_span=1w"`
+		To   time.Time
+	}
+}
+
+func TestValidateDateStruct(t *testing.T) {
+	since := "2024-07-01"
+	loc := getTestTimezone()
+	tests := []struct {
+		name     string
+		req      dateRangeRequest
+		expected []DateErrorCode
+	}{
+		{"valid", dateRangeRequest{StartDay: "20240701", EndDay: "20240731"}, nil},
+		{"required", dateRangeRequest{EndDay: "20240731"}, []DateErrorCode{DateErrRequired}},
+		{"format", dateRangeRequest{StartDay: "2024-07-01", EndDay: "20240731"}, []DateErrorCode{DateErrFormat}},
+		{"reversed", dateRangeRequest{StartDay: "20240801", EndDay: "20240731"}, []DateErrorCode{DateErrLteField}},
+		{"span", dateRangeRequest{StartDay: "20240601", EndDay: "20240731"}, []DateErrorCode{DateErrMaxSpan}},
+		{"pointer", dateRangeRequest{StartDay: "20240702", EndDay: "20240731", Since: &since}, []DateErrorCode{DateErrGteField}},
+		{"nested", func() dateRangeRequest {
+			req := dateRangeRequest{StartDay: "20240701", EndDay: "20240731"}
+			req.Filter.From = time.Date(2024, 7, 1, 0, 0, 0, 0, loc)
+			req.Filter.To = time.Date(2024, 7, 10, 0, 0, 0, 0, loc)
+			return req
+		}(), []DateErrorCode{DateErrMaxSpan}},
+	}
+	for _, test := range tests {
+		err := ValidateDateStruct(&test.req)
+		var codes []DateErrorCode
+		var errs DateValidationErrors
+		if errors.As(err, &errs) {
+			for _, e := range errs {
+				codes = append(codes, e.Code)
+			}
+		}
+		if !reflect.DeepEqual(codes, test.expected) {
+			t.Errorf("%s: ValidateDateStruct() = %v; want %v", test.name, err, test.expected)
+		}
+	}
+
+	err := ValidateDateStruct(dateRangeRequest{StartDay: "20240801", EndDay: "20240731"})
+	var errs DateValidationErrors
+	if !errors.As(err, &errs) || errs[0].Message(LocaleZhCN) != "StartDay不能晚于EndDay" {
+		t.Errorf("ValidateDateStruct() message = %v", err)
+	}
+	err = ValidateDateStruct(dateRangeRequest{StartDay: "20240601", EndDay: "20240731"})
+	if !errors.As(err, &errs) || errs[0].Message(LocaleEnUS) != "StartDay must be within 31d of EndDay" {
+		t.Errorf("ValidateDateStruct() message = %v", err)
+	}
+	if err := ValidateDateStruct("20240101"); err == nil {
+		t.Errorf("ValidateDateStruct(string) = nil; want error")
+	}
+}
+
+// testFieldLevel 模拟validator.FieldLevel
+type testFieldLevel struct {
+	parent any
+	name   string
+	param  string
+}
+
+func (fl testFieldLevel) Field() reflect.Value {
+	return reflect.ValueOf(fl.parent).FieldByName(fl.name)
+}
+func (fl testFieldLevel) Parent() reflect.Value   { return reflect.ValueOf(fl.parent) }
+func (fl testFieldLevel) StructFieldName() string { return fl.name }
+func (fl testFieldLevel) Param() string           { return fl.param }
+
+func TestDateTagValidators(t *testing.T) {
+	validators := DateTagValidators()
+	req := dateRangeRequest{StartDay: "20240701", EndDay: "20240815"}
+	tests := []struct {
+		tag      string
+		fl       FieldLevel
+		expected bool
+	}{
+		{"date", testFieldLevel{req, "StartDay", "20060102"}, true},
+		{"date", testFieldLevel{req, "StartDay", "2006-01-02"}, false},
+		{"date", testFieldLevel{req, "Since", "2006-01-02"}, true},
+		{"lte_field", testFieldLevel{req, "StartDay", "EndDay"}, true},
+		{"gte_field", testFieldLevel{req, "StartDay", "EndDay"}, false},
+		{"max_span", testFieldLevel{req, "StartDay", "31d"}, false},
+		{"max_span", testFieldLevel{req, "StartDay", "7w"}, true},
+		{"max_span", testFieldLevel{req, "StartDay", "bad"}, false},
+	}
+	for _, test := range tests {
+		if got := validators[test.tag](test.fl); got != test.expected {
+			t.Errorf("%s=%s on %s = %v; want %v", test.tag, test.fl.Param(), test.fl.StructFieldName(), got, test.expected)
+		}
+	}
+}
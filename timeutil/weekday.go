@@ -0,0 +1,115 @@
+package timeutil
+
+import (
+	"time"
+)
+
+// NthWeekdayOfMonth 获取某年某月第n个星期几的零点，n为负数时从月末倒数，-1为最后一个；不存在时返回false
+func NthWeekdayOfMonth(year int, month time.Month, weekday time.Weekday, n int, timezone *time.Location) (time.Time, bool) {
+	if n == 0 {
+		return time.Time{}, false
+	}
+	var day int
+	if n > 0 {
+		first := time.Date(year, month, 1, 0, 0, 0, 0, timezone).Weekday()
+		day = 1 + (int(weekday)-int(first)+7)%7 + (n-1)*7
+	} else {
+		lastDay := daysInMonth(year, month)
+		last := time.Date(year, month, lastDay, 0, 0, 0, 0, timezone).Weekday()
+		day = lastDay - (int(last)-int(weekday)+7)%7 + (n+1)*7
+	}
+	if day < 1 || day > daysInMonth(year, month) {
+		return time.Time{}, false
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, timezone), true
+}
+
+// LastWeekdayOfMonth 获取某年某月最后一个星期几的零点
+func LastWeekdayOfMonth(year int, month time.Month, weekday time.Weekday, timezone *time.Location) time.Time {
+	t, _ := NthWeekdayOfMonth(year, month, weekday, -1, timezone)
+	return t
+}
+
+// NextWeekday 获取t之后(不含当天)的第一个星期几，保留时分秒
+func NextWeekday(t time.Time, weekday time.Weekday) time.Time {
+	return NthWeekdayAfter(t, weekday, 1)
+}
+
+// PreviousWeekday 获取t之前(不含当天)的最近一个星期几，保留时分秒
+func PreviousWeekday(t time.Time, weekday time.Weekday) time.Time {
+	return NthWeekdayAfter(t, weekday, -1)
+}
+
+// NthWeekdayAfter 获取t之后(不含当天)的第n个星期几，n为负数时向前查找，保留时分秒
+func NthWeekdayAfter(t time.Time, weekday time.Weekday, n int) time.Time {
+	if n == 0 {
+		return t
+	}
+	if n > 0 {
+		offset := (int(weekday)-int(t.Weekday())+6)%7 + 1
+		return t.AddDate(0, 0, offset+(n-1)*7)
+	}
+	offset := (int(t.Weekday())-int(weekday)+6)%7 + 1
+	return t.AddDate(0, 0, -offset+(n+1)*7)
+}
+
+// WeekdayOfWeek 获取t所在周偏移weekOffset周后的星期几，weekStart为每周的第一天，保留时分秒。
+// 例如"上周一"为WeekdayOfWeek(t, time.Monday, -1, time.Monday)
+func WeekdayOfWeek(t time.Time, weekday time.Weekday, weekOffset int, weekStart time.Weekday) time.Time {
+	current := (int(t.Weekday()) - int(weekStart) + 7) % 7
+	target := (int(weekday) - int(weekStart) + 7) % 7
+	return t.AddDate(0, 0, target-current+weekOffset*7)
+}
+
+// AddWeekdays t加减n个工作日(周一至周五)，t为周末时从下一个工作日(n<0时为上一个工作日)起算，保留时分秒
+func AddWeekdays(t time.Time, n int) time.Time {
+	step := 1
+	if n < 0 {
+		step, n = -1, -n
+	}
+	for n > 0 {
+		t = t.AddDate(0, 0, step)
+		if t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			n--
+		}
+	}
+	return t
+}
+
+// GetNthWeekdayOfMonth 获取day所在月份第n个星期几，n为负数时从月末倒数；格式YYYYMMDD，不存在时返回空字符串
+func GetNthWeekdayOfMonth(day string, weekday time.Weekday, n int, timezone *time.Location) string {
+	current := Str2Time(day, FormatYYYYMMDDNoSymbol, timezone)
+	t, ok := NthWeekdayOfMonth(current.Year(), current.Month(), weekday, n, timezone)
+	if !ok {
+		return ""
+	}
+	return t.Format(FormatYYYYMMDDNoSymbol)
+}
+
+// GetNextWeekday 获取day之后的第一个星期几；格式YYYYMMDD
+func GetNextWeekday(day string, weekday time.Weekday, timezone *time.Location) string {
+	return GetNthWeekdayAfter(day, weekday, 1, timezone)
+}
+
+// GetPreviousWeekday 获取day之前的最近一个星期几；格式YYYYMMDD
+func GetPreviousWeekday(day string, weekday time.Weekday, timezone *time.Location) string {
+	return GetNthWeekdayAfter(day, weekday, -1, timezone)
+}
+
+// GetNthWeekdayAfter 获取day之后的第n个星期几，n为负数时向前查找；格式YYYYMMDD
+func GetNthWeekdayAfter(day string, weekday time.Weekday, n int, timezone *time.Location) string {
+	current := Str2Time(day, FormatYYYYMMDDNoSymbol, timezone)
+	return NthWeekdayAfter(current, weekday, n).Format(FormatYYYYMMDDNoSymbol)
+}
+
+// GetWeekdayOfWeek 获取day所在周偏移weekOffset周后的星期几，weekStart为每周的第一天；格式YYYYMMDD
+func GetWeekdayOfWeek(day string, weekday time.Weekday, weekOffset int, weekStart time.Weekday, timezone *time.Location) string {
+	current := Str2Time(day, FormatYYYYMMDDNoSymbol, timezone)
+	return WeekdayOfWeek(current, weekday, weekOffset, weekStart).Format(FormatYYYYMMDDNoSymbol)
+}
+
+// AddWeekdaysBiDay day加减n个工作日(周一至周五)；格式YYYYMMDD
+func AddWeekdaysBiDay(day string, n int, timezone *time.Location) string {
+	current := Str2Time(day, FormatYYYYMMDDNoSymbol, timezone)
+	return AddWeekdays(current, n).Format(FormatYYYYMMDDNoSymbol)
+}
@@ -0,0 +1,76 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNthWeekdayOfMonth(t *testing.T) {
+	loc := getTestTimezone()
+	tests := []struct {
+		name     string
+		year     int
+		month    time.Month
+		weekday  time.Weekday
+		n        int
+		expected string
+		ok       bool
+	}{
+		{"second wednesday", 2024, time.July, time.Wednesday, 2, "20240710", true},
+		{"first monday", 2024, time.July, time.Monday, 1, "20240701", true},
+		{"last friday", 2024, time.July, time.Friday, -1, "20240726", true},
+		{"second to last sunday", 2024, time.July, time.Sunday, -2, "20240721", true},
+		{"fifth thursday", 2024, time.February, time.Thursday, 5, "20240229", true},
+		{"no fifth friday", 2024, time.February, time.Friday, 5, "", false},
+		{"zero", 2024, time.February, time.Friday, 0, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NthWeekdayOfMonth(tt.year, tt.month, tt.weekday, tt.n, loc)
+			if ok != tt.ok || (ok && got.Format(FormatYYYYMMDDNoSymbol) != tt.expected) {
+				t.Errorf("NthWeekdayOfMonth() = %v, %v; want %v, %v", got, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+	if got := LastWeekdayOfMonth(2024, time.November, time.Thursday, loc); got.Format(FormatYYYYMMDDNoSymbol) != "20241128" {
+		t.Errorf("LastWeekdayOfMonth() = %v; want 20241128", got)
+	}
+}
+
+func TestRelativeWeekday(t *testing.T) {
+	loc := getTestTimezone()
+	tests := []struct {
+		name     string
+		got      string
+		expected string
+	}{
+		{"next monday from sunday", GetNextWeekday("20240728", time.Monday, loc), "20240729"},
+		{"next sunday from sunday", GetNextWeekday("20240728", time.Sunday, loc), "20240804"},
+		{"previous sunday from sunday", GetPreviousWeekday("20240728", time.Sunday, loc), "20240721"},
+		{"previous friday", GetPreviousWeekday("20240728", time.Friday, loc), "20240726"},
+		{"third tuesday after", GetNthWeekdayAfter("20240723", time.Tuesday, 3, loc), "20240813"},
+		{"second wednesday before", GetNthWeekdayAfter("20240728", time.Wednesday, -2, loc), "20240717"},
+		{"last week monday", GetWeekdayOfWeek("20240728", time.Monday, -1, time.Monday, loc), "20240715"},
+		{"this week monday", GetWeekdayOfWeek("20240728", time.Monday, 0, time.Monday, loc), GetDayOfWeek("20240728", 1, loc)},
+		{"next week monday with sunday start", GetWeekdayOfWeek("20240728", time.Monday, 1, time.Sunday, loc), "20240805"},
+		{"month of day", GetNthWeekdayOfMonth("20240728", time.Wednesday, 2, loc), "20240710"},
+		{"add weekdays over weekend", AddWeekdaysBiDay("20240726", 1, loc), "20240729"},
+		{"add weekdays from weekend", AddWeekdaysBiDay("20240727", 2, loc), "20240730"},
+		{"subtract weekdays", AddWeekdaysBiDay("20240729", -3, loc), "20240724"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.expected {
+				t.Errorf("got %v, want %v", tt.got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNextWeekdayKeepsClock(t *testing.T) {
+	tm := fixedTime(getTestTimezone())
+	expected := time.Date(2024, time.August, 2, 10, 15, 30, 0, getTestTimezone())
+	if got := NextWeekday(tm, time.Friday); !got.Equal(expected) {
+		t.Errorf("NextWeekday(%v, Friday) = %v; want %v", tm, got, expected)
+	}
+}
@@ -0,0 +1,75 @@
+package timeutil
+
+import (
+	"time"
+)
+
+// TumblingWindow 获取事件时间t所属的滚动窗口，窗口按period在timezone下划分，例如PeriodOf(5*time.Minute)、DailyPeriod()
+func TumblingWindow(t time.Time, period Period, timezone *time.Location) Interval {
+	start := period.Start(t, timezone)
+	return Interval{Start: start, End: period.Next(start, timezone)}
+}
+
+// SlidingWindows 获取事件时间t所属的所有滑动窗口，按开始时间升序。
+// 窗口长度为size，每隔slide开始一个新窗口，窗口起点按timezone的零点对齐，slide应能整除一天；
+// slide大于size时窗口之间存在空档，t可能不属于任何窗口
+func SlidingWindows(t time.Time, size time.Duration, slide time.Duration, timezone *time.Location) []Interval {
+	if size <= 0 || slide <= 0 {
+		return nil
+	}
+	var ret []Interval
+	for start := PeriodOf(slide).Start(t, timezone); start.Add(size).After(t); start = start.Add(-slide) {
+		ret = append(ret, Interval{Start: start, End: start.Add(size)})
+	}
+	for i, j := 0, len(ret)-1; i < j; i, j = i+1, j-1 {
+		ret[i], ret[j] = ret[j], ret[i]
+	}
+	return ret
+}
+
+// SessionWindow 单个事件的会话窗口 [t, t+gap)
+func SessionWindow(t time.Time, gap time.Duration) Interval {
+	return Interval{Start: t, End: t.Add(gap)}
+}
+
+// SessionWindows 将事件时间合并为会话窗口，相邻事件间隔不超过gap时属于同一会话
+func SessionWindows(times []time.Time, gap time.Duration) []Interval {
+	windows := make([]Interval, 0, len(times))
+	for _, t := range times {
+		windows = append(windows, SessionWindow(t, gap))
+	}
+	return MergeIntervals(windows)
+}
+
+// Watermark 根据已观察到的最大事件时间和允许的最大乱序时长计算水位线
+func Watermark(maxEventTime time.Time, maxOutOfOrderness time.Duration) time.Time {
+	return maxEventTime.Add(-maxOutOfOrderness)
+}
+
+// IsWindowReady 水位线是否已越过窗口结束时间，即窗口可以触发计算
+func IsWindowReady(w Interval, watermark time.Time) bool {
+	return !watermark.Before(w.End)
+}
+
+// WindowCleanupTime 窗口在允许迟到时长allowedLateness之后的清理时间
+func WindowCleanupTime(w Interval, allowedLateness time.Duration) time.Time {
+	return w.End.Add(allowedLateness)
+}
+
+// IsWindowExpired 水位线是否已越过窗口清理时间，此后到达的事件对该窗口而言是迟到数据，应丢弃或旁路输出
+func IsWindowExpired(w Interval, watermark time.Time, allowedLateness time.Duration) bool {
+	return !watermark.Before(WindowCleanupTime(w, allowedLateness))
+}
+
+// IsLateEvent 事件所属的窗口是否都已过期，windows为空时(事件不属于任何窗口)返回false
+func IsLateEvent(windows []Interval, watermark time.Time, allowedLateness time.Duration) bool {
+	if len(windows) == 0 {
+		return false
+	}
+	for _, w := range windows {
+		if !IsWindowExpired(w, watermark, allowedLateness) {
+			return false
+		}
+	}
+	return true
+}
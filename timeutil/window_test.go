@@ -0,0 +1,75 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTumblingWindow(t *testing.T) {
+	loc := getTestTimezone()
+	tm := fixedTime(loc)
+	expected := Interval{Start: GetTime5Minute(tm.Unix(), loc), End: GetTime5Minute(tm.Unix(), loc).Add(5 * time.Minute)}
+	if got := TumblingWindow(tm, PeriodOf(5*time.Minute), loc); !equalIntervals([]Interval{got}, []Interval{expected}) {
+		t.Errorf("TumblingWindow() = %v, want %v", got, expected)
+	}
+}
+
+func TestSlidingWindows(t *testing.T) {
+	loc := getTestTimezone()
+	at := func(minute int) time.Time {
+		return time.Date(2024, 7, 28, 10, minute, 0, 0, loc)
+	}
+	tests := []struct {
+		name     string
+		t        time.Time
+		size     time.Duration
+		slide    time.Duration
+		expected []Interval
+	}{
+		{"hopping", at(7), 10 * time.Minute, 5 * time.Minute,
+			[]Interval{{at(0), at(10)}, {at(5), at(15)}}},
+		{"on boundary", at(10), 15 * time.Minute, 5 * time.Minute,
+			[]Interval{{at(0), at(15)}, {at(5), at(20)}, {at(10), at(25)}}},
+		{"many windows", at(7), 5 * time.Minute, time.Minute,
+			[]Interval{{at(3), at(8)}, {at(4), at(9)}, {at(5), at(10)}, {at(6), at(11)}, {at(7), at(12)}}},
+		{"gap between windows", at(7), 5 * time.Minute, 10 * time.Minute, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SlidingWindows(tt.t, tt.size, tt.slide, loc); !equalIntervals(got, tt.expected) {
+				t.Errorf("SlidingWindows() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSessionWindows(t *testing.T) {
+	loc := getTestTimezone()
+	at := func(minute int) time.Time {
+		return time.Date(2024, 7, 28, 10, minute, 0, 0, loc)
+	}
+	times := []time.Time{at(20), at(0), at(3), at(8), at(24)}
+	expected := []Interval{{at(0), at(13)}, {at(20), at(29)}}
+	if got := SessionWindows(times, 5*time.Minute); !equalIntervals(got, expected) {
+		t.Errorf("SessionWindows() = %v, want %v", got, expected)
+	}
+}
+
+func TestWatermark(t *testing.T) {
+	loc := getTestTimezone()
+	w := Interval{Start: time.Date(2024, 7, 28, 10, 0, 0, 0, loc), End: time.Date(2024, 7, 28, 10, 5, 0, 0, loc)}
+	watermark := Watermark(time.Date(2024, 7, 28, 10, 6, 0, 0, loc), 30*time.Second)
+
+	if !IsWindowReady(w, watermark) {
+		t.Errorf("IsWindowReady(%v, %v) = false; want true", w, watermark)
+	}
+	if IsWindowExpired(w, watermark, time.Minute) {
+		t.Errorf("IsWindowExpired(%v, %v, 1m) = true; want false", w, watermark)
+	}
+	if !IsLateEvent([]Interval{w}, watermark, 30*time.Second) {
+		t.Errorf("IsLateEvent(%v, %v, 30s) = false; want true", w, watermark)
+	}
+	if IsLateEvent(nil, watermark, 30*time.Second) {
+		t.Errorf("IsLateEvent(nil, %v, 30s) = true; want false", watermark)
+	}
+}
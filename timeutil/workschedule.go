@@ -0,0 +1,151 @@
+package timeutil
+
+import (
+	"time"
+)
+
+// WorkSchedule 工作时间表，工作日按Calendar判断，每个工作日的工作时段为Periods，用于计算只计工作时间的SLA时长
+type WorkSchedule struct {
+	Periods  []DayPeriod    // 每个工作日的工作时段，按时间顺序且不重叠，例如09:00-12:00、13:00-18:00
+	Calendar *Calendar      // 工作日历，为nil时周一至周五为工作日
+	Timezone *time.Location // 工作时段所在的时区，为nil时为UTC
+}
+
+// StandardWorkSchedule 周一至周五(按calendar调整)09:00-12:00、13:00-18:00的工作时间表，中午休息1小时
+func StandardWorkSchedule(calendar *Calendar, timezone *time.Location) WorkSchedule {
+	return WorkSchedule{
+		Periods:  MustParseDayPeriods("09:00-12:00,13:00-18:00"),
+		Calendar: calendar,
+		Timezone: timezone,
+	}
+}
+
+func (w WorkSchedule) timezone() *time.Location {
+	if w.Timezone == nil {
+		return TimezoneUtc
+	}
+	return w.Timezone
+}
+
+// IsWorkday t在工作时间表时区下所在日是否为工作日
+func (w WorkSchedule) IsWorkday(t time.Time) bool {
+	if w.Calendar == nil {
+		return !IsWeekend(t, w.timezone())
+	}
+	return w.Calendar.IsWorkday(t, w.timezone())
+}
+
+// WorkingPeriods t所在日的工作时段，非工作日返回nil
+func (w WorkSchedule) WorkingPeriods(t time.Time) []Interval {
+	if !w.IsWorkday(t) {
+		return nil
+	}
+	day := StartOfDay(t, w.timezone())
+	ret := make([]Interval, 0, len(w.Periods))
+	for _, p := range w.Periods {
+		ret = append(ret, p.On(day, w.timezone()))
+	}
+	return ret
+}
+
+// IsWorkingTime t是否在工作时间内，工作时段左闭右开
+func (w WorkSchedule) IsWorkingTime(t time.Time) bool {
+	for _, p := range w.WorkingPeriods(t) {
+		if p.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// NextWorkingTime t及之后的第一个工作时刻，t在工作时间内时返回t；一年内没有工作时段时返回零值
+func (w WorkSchedule) NextWorkingTime(t time.Time) time.Time {
+	day := StartOfDay(t, w.timezone())
+	for i := 0; i <= maxMarketScanDays; i++ {
+		for _, p := range w.WorkingPeriods(day.AddDate(0, 0, i)) {
+			if p.Contains(t) {
+				return t
+			}
+			if p.Start.After(t) {
+				return p.Start
+			}
+		}
+	}
+	return time.Time{}
+}
+
+// BusinessDuration from到to之间的工作时长，from晚于to时返回负数
+func (w WorkSchedule) BusinessDuration(from, to time.Time) time.Duration {
+	if to.Before(from) {
+		return -w.BusinessDuration(to, from)
+	}
+	span := Interval{Start: from, End: to}
+	var total time.Duration
+	for day := StartOfDay(from, w.timezone()); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, p := range w.WorkingPeriods(day) {
+			total += p.Intersect(span).Duration()
+		}
+	}
+	return total
+}
+
+// AddBusinessDuration t加上d的工作时长，d为负数时向前减去，例如工单创建后4个工作小时的截止时间。
+// t不在工作时间内时从下一个(d为负数时为上一个)工作时段开始计算；恰好用完时返回所在工作时段的结束(或开始)时刻。
+// 连续一年没有工作时段时返回零值
+func (w WorkSchedule) AddBusinessDuration(t time.Time, d time.Duration) time.Time {
+	if d == 0 {
+		return t
+	}
+	day := StartOfDay(t, w.timezone())
+	if d > 0 {
+		for i, idle := 0, 0; idle <= maxMarketScanDays; i++ {
+			periods := w.WorkingPeriods(day.AddDate(0, 0, i))
+			if len(periods) == 0 {
+				idle++
+			} else {
+				idle = 0
+			}
+			for _, p := range periods {
+				if !p.End.After(t) {
+					continue
+				}
+				start := p.Start
+				if start.Before(t) {
+					start = t
+				}
+				left := p.End.Sub(start)
+				if d <= left {
+					return start.Add(d)
+				}
+				d -= left
+			}
+		}
+		return time.Time{}
+	}
+
+	d = -d
+	for i, idle := 0, 0; idle <= maxMarketScanDays; i++ {
+		periods := w.WorkingPeriods(day.AddDate(0, 0, -i))
+		if len(periods) == 0 {
+			idle++
+		} else {
+			idle = 0
+		}
+		for j := len(periods) - 1; j >= 0; j-- {
+			p := periods[j]
+			if !p.Start.Before(t) {
+				continue
+			}
+			end := p.End
+			if end.After(t) {
+				end = t
+			}
+			left := end.Sub(p.Start)
+			if d <= left {
+				return end.Add(-d)
+			}
+			d -= left
+		}
+	}
+	return time.Time{}
+}
@@ -0,0 +1,78 @@
+package timeutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWorkSchedule(t *testing.T) {
+	loc := TimezoneShanghai
+	w := StandardWorkSchedule(ChinaCalendar(), loc)
+	at := func(day string, hour, minute int) time.Time {
+		d := Str2Time(day, FormatYYYYMMDDNoSymbol, loc)
+		return time.Date(d.Year(), d.Month(), d.Day(), hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		t       time.Time
+		working bool
+		next    time.Time
+	}{
+		{at("20240701", 8, 0), false, at("20240701", 9, 0)},
+		{at("20240701", 10, 0), true, at("20240701", 10, 0)},
+		{at("20240701", 12, 30), false, at("20240701", 13, 0)},
+		{at("20240701", 18, 0), false, at("20240702", 9, 0)},
+		{at("20240928", 8, 0), false, at("20240929", 9, 0)}, // 周日调休上班
+		{at("20240930", 18, 0), false, at("20241008", 9, 0)},
+	}
+	for _, test := range tests {
+		if got := w.IsWorkingTime(test.t); got != test.working {
+			t.Errorf("IsWorkingTime(%v) = %v; want %v", test.t, got, test.working)
+		}
+		if got := w.NextWorkingTime(test.t); !got.Equal(test.next) {
+			t.Errorf("NextWorkingTime(%v) = %v; want %v", test.t, got, test.next)
+		}
+	}
+
+	if got := w.BusinessDuration(at("20240705", 17, 0), at("20240708", 10, 30)); got != 150*time.Minute {
+		t.Errorf("BusinessDuration(weekend) = %v; want 2h30m", got)
+	}
+	if got := w.BusinessDuration(at("20240708", 10, 30), at("20240705", 17, 0)); got != -150*time.Minute {
+		t.Errorf("BusinessDuration(reversed) = %v; want -2h30m", got)
+	}
+	if got := w.BusinessDuration(at("20240701", 0, 0), at("20240708", 0, 0)); got != 40*time.Hour {
+		t.Errorf("BusinessDuration(week) = %v; want 40h", got)
+	}
+
+	addTests := []struct {
+		from     time.Time
+		d        time.Duration
+		expected time.Time
+	}{
+		{at("20240701", 11, 0), 2 * time.Hour, at("20240701", 14, 0)},
+		{at("20240701", 12, 30), time.Hour, at("20240701", 14, 0)},
+		{at("20240705", 17, 0), 4 * time.Hour, at("20240708", 12, 0)},
+		{at("20240701", 9, 0), 3 * time.Hour, at("20240701", 12, 0)},
+		{at("20240930", 16, 0), 8 * time.Hour, at("20241008", 16, 0)},
+		{at("20240701", 14, 0), -2 * time.Hour, at("20240701", 11, 0)},
+		{at("20240708", 10, 0), -2 * time.Hour, at("20240705", 17, 0)},
+		{at("20240701", 10, 0), 0, at("20240701", 10, 0)},
+	}
+	for _, test := range addTests {
+		got := w.AddBusinessDuration(test.from, test.d)
+		if !got.Equal(test.expected) {
+			t.Errorf("AddBusinessDuration(%v, %v) = %v; want %v", test.from, test.d, got, test.expected)
+		}
+		if test.d > 0 && w.BusinessDuration(test.from, got) != test.d {
+			t.Errorf("BusinessDuration(%v, %v) != %v", test.from, got, test.d)
+		}
+	}
+
+	utc := WorkSchedule{Periods: MustParseDayPeriods("09:00-17:00")}
+	if !utc.IsWorkingTime(time.Date(2024, 7, 26, 9, 0, 0, 0, TimezoneUtc)) || utc.IsWorkday(time.Date(2024, 7, 27, 9, 0, 0, 0, TimezoneUtc)) {
+		t.Errorf("zero-value Calendar and Timezone should mean Mon-Fri in UTC")
+	}
+	if got := (WorkSchedule{}).AddBusinessDuration(at("20240701", 9, 0), time.Hour); !got.IsZero() {
+		t.Errorf("AddBusinessDuration() without periods = %v; want zero", got)
+	}
+}